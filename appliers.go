@@ -11,25 +11,42 @@ var appliers []applier
 
 func init() {
 	appliers = []applier{
-		interfaceApplier,
 		settableTestApplier,
+		deepCopyApplier,
+		interfaceApplier,
 		matchedTypeApplier,
+		elemApplier,
+		funcApplier,
+		enumApplier,
+		sqlApplier,
+		formatApplier,
+		textApplier,
+		bytesApplier,
 		pointerApplier,
+		mapSliceApplier,
 		sliceApplier,
+		mapStructApplier,
 		mapApplier,
 		structApplier,
 		intApplier,
 		uintApplier,
 		floatApplier,
+		complexApplier,
 		stringApplier,
 	}
 }
 
-type applier func(reflect.Value, reflect.Value) (bool, error)
+type applier func(*state, reflect.Value, reflect.Value) (bool, error)
+
+func (s *state) applyField(iField reflect.Value, vField reflect.Value) error {
+	s.depth++
+	defer func() { s.depth-- }()
+	if s.maxDepth > 0 && s.depth > s.maxDepth {
+		return &DepthError{Limit: s.maxDepth}
+	}
 
-func applyField(iField reflect.Value, vField reflect.Value) error {
 	for _, applier := range appliers {
-		applied, err := applier(iField, vField)
+		applied, err := applier(s, iField, vField)
 		if applied || err != nil {
 			return err
 		}
@@ -40,7 +57,7 @@ func applyField(iField reflect.Value, vField reflect.Value) error {
 	return fmt.Errorf("could not apply type '%v' to '%v'", iField.Type(), vField.Type())
 }
 
-func intApplier(iField reflect.Value, vField reflect.Value) (bool, error) {
+func intApplier(s *state, iField reflect.Value, vField reflect.Value) (bool, error) {
 	if !iField.IsValid() || !vField.IsValid() {
 		return false, nil
 	}
@@ -60,12 +77,18 @@ func intApplier(iField reflect.Value, vField reflect.Value) (bool, error) {
 		value = int64(iField.Uint())
 	case reflect.Float32, reflect.Float64:
 		value = int64(iField.Float())
+	case reflect.Complex64, reflect.Complex128:
+		f, err := realPart(iField, vField.Type())
+		if err != nil {
+			return false, err
+		}
+		value = int64(f)
 	case reflect.String:
-		valInt, err := strconv.Atoi(iField.String())
+		var err error
+		value, err = s.parseInt(iField.String(), vField.Type().Bits())
 		if err != nil {
 			return false, err
 		}
-		value = int64(valInt)
 	default:
 		return false, nil
 	}
@@ -74,7 +97,7 @@ func intApplier(iField reflect.Value, vField reflect.Value) (bool, error) {
 	return true, nil
 }
 
-func uintApplier(iField reflect.Value, vField reflect.Value) (bool, error) {
+func uintApplier(s *state, iField reflect.Value, vField reflect.Value) (bool, error) {
 	if !iField.IsValid() || !vField.IsValid() {
 		return false, nil
 	}
@@ -94,12 +117,18 @@ func uintApplier(iField reflect.Value, vField reflect.Value) (bool, error) {
 		value = iField.Uint()
 	case reflect.Float32, reflect.Float64:
 		value = uint64(iField.Float())
+	case reflect.Complex64, reflect.Complex128:
+		f, err := realPart(iField, vField.Type())
+		if err != nil {
+			return false, err
+		}
+		value = uint64(f)
 	case reflect.String:
-		valInt, err := strconv.Atoi(iField.String())
+		var err error
+		value, err = s.parseUint(iField.String(), vField.Type().Bits())
 		if err != nil {
 			return false, err
 		}
-		value = uint64(valInt)
 	default:
 		return false, nil
 	}
@@ -108,7 +137,7 @@ func uintApplier(iField reflect.Value, vField reflect.Value) (bool, error) {
 	return true, nil
 }
 
-func floatApplier(iField reflect.Value, vField reflect.Value) (bool, error) {
+func floatApplier(s *state, iField reflect.Value, vField reflect.Value) (bool, error) {
 	if !iField.IsValid() || !vField.IsValid() {
 		return false, nil
 	}
@@ -134,8 +163,13 @@ func floatApplier(iField reflect.Value, vField reflect.Value) (bool, error) {
 		value, _ = strconv.ParseFloat(fmt.Sprint(float32(iField.Float())), bitSize)
 	case reflect.Float64:
 		value = iField.Float()
+	case reflect.Complex64, reflect.Complex128:
+		value, err = realPart(iField, vField.Type())
+		if err != nil {
+			return false, err
+		}
 	case reflect.String:
-		value, err = strconv.ParseFloat(iField.String(), bitSize)
+		value, err = s.parseFloat(iField.String(), bitSize)
 		if err != nil {
 			return false, err
 		}
@@ -147,7 +181,7 @@ func floatApplier(iField reflect.Value, vField reflect.Value) (bool, error) {
 	return true, nil
 }
 
-func stringApplier(iField reflect.Value, vField reflect.Value) (bool, error) {
+func stringApplier(s *state, iField reflect.Value, vField reflect.Value) (bool, error) {
 	if !iField.IsValid() || !vField.IsValid() {
 		return false, nil
 	}
@@ -159,7 +193,7 @@ func stringApplier(iField reflect.Value, vField reflect.Value) (bool, error) {
 	return true, nil
 }
 
-func interfaceApplier(iField reflect.Value, vField reflect.Value) (bool, error) {
+func interfaceApplier(s *state, iField reflect.Value, vField reflect.Value) (bool, error) {
 	if !iField.IsValid() || !vField.IsValid() {
 		return false, nil
 	}
@@ -167,11 +201,30 @@ func interfaceApplier(iField reflect.Value, vField reflect.Value) (bool, error)
 		return false, nil
 	}
 
+	if s.deepCopy && iField.Kind() != reflect.Interface && isReference(iField.Type(), s.unexportedFields) {
+		copied := reflect.New(iField.Type())
+		err := s.applyField(iField, copied.Elem())
+		if err != nil {
+			return false, err
+		}
+		iField = copied.Elem()
+	}
+
+	if s.mergeMaps && !vField.IsNil() && vField.Elem().Kind() == reflect.Map && concrete(iField).Kind() == reflect.Map {
+		merged := reflect.New(vField.Elem().Type())
+		merged.Elem().Set(vField.Elem())
+		err := s.applyField(iField, merged.Elem())
+		if err == nil {
+			vField.Set(merged.Elem())
+		}
+		return err == nil, err
+	}
+
 	vField.Set(iField)
 	return true, nil
 }
 
-func sliceApplier(iField reflect.Value, vField reflect.Value) (bool, error) {
+func sliceApplier(s *state, iField reflect.Value, vField reflect.Value) (bool, error) {
 	if !iField.IsValid() || !vField.IsValid() {
 		return false, nil
 	}
@@ -183,9 +236,12 @@ func sliceApplier(iField reflect.Value, vField reflect.Value) (bool, error) {
 	}
 
 	for i := 0; i < iField.Len(); i++ {
+		if err := s.ctx.Err(); err != nil {
+			return false, err
+		}
 		iValue := iField.Index(i)
 		appendVal := reflect.New(vField.Type().Elem())
-		err := applyField(iValue, appendVal.Elem())
+		err := s.applyField(iValue, appendVal.Elem())
 		if err != nil {
 			return false, err
 		}
@@ -195,25 +251,73 @@ func sliceApplier(iField reflect.Value, vField reflect.Value) (bool, error) {
 }
 
 // settableTestApplier drops handling for any unsettable fields
-func settableTestApplier(iField reflect.Value, vField reflect.Value) (bool, error) {
+func settableTestApplier(s *state, iField reflect.Value, vField reflect.Value) (bool, error) {
 	if !vField.CanSet() {
 		return true, nil
 	}
 	return false, nil
 }
 
-func matchedTypeApplier(iField reflect.Value, vField reflect.Value) (bool, error) {
+func matchedTypeApplier(s *state, iField reflect.Value, vField reflect.Value) (bool, error) {
 	if !iField.IsValid() || !vField.IsValid() {
 		return false, nil
 	}
 	if iField.Type() == vField.Type() {
+		if s.fieldwise(iField, vField) {
+			// Apply element by element so transforms and mappings are used and maps are merged,
+			// starting from a copy of any unexported struct fields.
+			if iField.Kind() == reflect.Struct {
+				previous := reflect.New(vField.Type()).Elem()
+				previous.Set(vField)
+				vField.Set(iField)
+				for i := 0; i < vField.NumField(); i++ {
+					if vField.Type().Field(i).PkgPath == "" {
+						vField.Field(i).Set(previous.Field(i))
+					}
+				}
+			}
+			return false, nil
+		}
 		vField.Set(iField)
 		return true, nil
 	}
 	return false, nil
 }
 
-func structApplier(iField reflect.Value, vField reflect.Value) (bool, error) {
+// fieldwise reports whether iField must be applied to vField of the same type element by element,
+// because it holds fields with transforms or mappings, or maps to be merged into vField.
+func (s *state) fieldwise(iField reflect.Value, vField reflect.Value) bool {
+	if iField.Kind() == reflect.Array {
+		return false
+	}
+	if s.mergeMaps && !vField.IsZero() && holdsMaps(vField.Type(), make(map[reflect.Type]bool)) {
+		return true
+	}
+	return s.hasTransforms(iField.Type())
+}
+
+// holdsMaps reports whether values of type t are maps, or structs and pointers holding maps.
+func holdsMaps(t reflect.Type, visited map[reflect.Type]bool) bool {
+	if visited[t] {
+		return false
+	}
+	visited[t] = true
+	switch t.Kind() {
+	case reflect.Map:
+		return true
+	case reflect.Ptr:
+		return holdsMaps(t.Elem(), visited)
+	case reflect.Struct:
+		for i := 0; i < t.NumField(); i++ {
+			if holdsMaps(t.Field(i).Type, visited) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func structApplier(s *state, iField reflect.Value, vField reflect.Value) (bool, error) {
 	if !iField.IsValid() || !vField.IsValid() {
 		return false, nil
 	}
@@ -223,34 +327,91 @@ func structApplier(iField reflect.Value, vField reflect.Value) (bool, error) {
 	if iField.Type().Kind() != reflect.Struct || vField.Type().Kind() != reflect.Struct {
 		return false, errors.New("cannot apply a struct type to a non-struct")
 	}
+	// Hooks are called on a copy of the source, so they cannot modify the original.
+	iPtr := reflect.New(iField.Type())
+	iPtr.Elem().Set(iField)
 	newPtr := reflect.New(vField.Type())
 	newPtr.Elem().Set(vField)
-	err := marshalStruct(iField.Interface(), newPtr.Interface())
+	err := s.marshalStruct(iPtr.Interface(), newPtr.Interface())
 	vField.Set(newPtr.Elem())
 	return err == nil, err
 }
 
-func marshalStruct(i interface{}, v interface{}) error {
+func (s *state) marshalStruct(i interface{}, v interface{}) error {
+	if err := s.beforeStructConvert(i, v); err != nil {
+		return err
+	}
+
+	m := s.mapping(i, v)
 	iFields := mapFields(i, v)
 	vFields := mapFields(v, i)
+	m.remap(iFields, vFields)
+	s.exposeFields(iFields)
+	s.exposeFields(vFields)
 
 	for name, iField := range iFields {
 		if vField, ok := vFields[name]; ok {
-			err := applyField(iField, vField)
+			if err := s.checkUnexported(i, iField, v, vField); err != nil {
+				return fmt.Errorf("%v: %w", name, err)
+			}
+			if (iField.PkgPath != "" || vField.PkgPath != "") && !s.unexportedFields {
+				continue
+			}
+			err := s.applyStructField(i, iField, vField)
 			if err != nil {
-				return fmt.Errorf("%v: %v", name, err)
+				return fmt.Errorf("%v: %w", name, err)
 			}
 		}
 	}
+	if err := s.applyMapping(m, i, v); err != nil {
+		return err
+	}
+
+	return s.afterStructConvert(i, v)
+}
+
+func (s *state) beforeStructConvert(i interface{}, v interface{}) error {
+	if hook, ok := i.(BeforeStructConverter); ok {
+		if err := hook.BeforeStructConvert(s.ctx, v); err != nil {
+			return err
+		}
+	}
+	if hook, ok := v.(BeforeStructConverter); ok {
+		if err := hook.BeforeStructConvert(s.ctx, i); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *state) afterStructConvert(i interface{}, v interface{}) error {
+	if hook, ok := i.(AfterStructConverter); ok {
+		if err := hook.AfterStructConvert(s.ctx, v); err != nil {
+			return err
+		}
+	}
+	if hook, ok := v.(AfterStructConverter); ok {
+		if err := hook.AfterStructConvert(s.ctx, i); err != nil {
+			return err
+		}
+	}
 	return nil
 }
 
-func pointerApplier(iField reflect.Value, vField reflect.Value) (bool, error) {
+func pointerApplier(s *state, iField reflect.Value, vField reflect.Value) (bool, error) {
 	if !iField.IsValid() || !vField.IsValid() {
 		return false, nil
 	}
 	if iField.Type().Kind() == reflect.Ptr {
-		err := applyField(reflect.Indirect(iField), vField)
+		if iField.IsNil() {
+			vField.Set(reflect.Zero(vField.Type()))
+			return true, nil
+		}
+		if vField.Type().Kind() == reflect.Ptr {
+			err := s.applyPointer(iField, vField)
+			return err == nil, err
+		}
+		err := s.applyElem(iField, vField)
 		return err == nil, err
 	}
 	iPtrType := reflect.PtrTo(iField.Type())
@@ -258,13 +419,17 @@ func pointerApplier(iField reflect.Value, vField reflect.Value) (bool, error) {
 		if iPtrType == vField.Type() {
 			newPtr := reflect.New(iField.Type())
 			newPtr.Elem().Set(iField)
-			err := applyField(newPtr, vField)
+			err := s.applyField(newPtr, vField)
 			return err == nil, err
 		}
 		t := reflect.TypeOf(vField.Interface())
-		if iField.Kind() == reflect.Struct && t.Elem().Kind() == reflect.Struct {
+		if isStructOrMap(iField.Type()) && isStructOrMap(t.Elem()) {
+			if s.mergeMaps && !vField.IsNil() {
+				err := s.applyField(iField, vField.Elem())
+				return err == nil, err
+			}
 			newPtr := reflect.New(t.Elem())
-			err := applyField(iField, newPtr.Elem())
+			err := s.applyField(iField, newPtr.Elem())
 			if err == nil {
 				vField.Set(newPtr)
 			}
@@ -274,7 +439,57 @@ func pointerApplier(iField reflect.Value, vField reflect.Value) (bool, error) {
 	return false, nil
 }
 
-func mapApplier(iField reflect.Value, vField reflect.Value) (bool, error) {
+// applyPointer applies the value referenced by iField to a new pointer for vField.
+// Each source pointer is converted once per target type, so cycles and shared
+// references in the source are reproduced in the target rather than recursing forever.
+func (s *state) applyPointer(iField reflect.Value, vField reflect.Value) error {
+	key := pointerKey{ptr: iField.Pointer(), from: iField.Type(), to: vField.Type()}
+	if target, ok := s.pointers[key]; ok {
+		vField.Set(target)
+		return nil
+	}
+
+	newPtr := reflect.New(vField.Type().Elem())
+	if s.mergeMaps && !vField.IsNil() {
+		newPtr = vField
+	}
+	s.pointers[key] = newPtr
+	err := s.applyField(iField.Elem(), newPtr.Elem())
+	if err != nil {
+		delete(s.pointers, key)
+		return err
+	}
+	vField.Set(newPtr)
+	return nil
+}
+
+// applyElem applies the value referenced by iField to the non-pointer vField.
+// If vField is addressable, its address is used for pointers to the same source value,
+// so cycles back to it are reproduced. Otherwise a pointer that is reached again while its
+// value is still being applied cannot be represented in the target, so it is reported as a cycle.
+func (s *state) applyElem(iField reflect.Value, vField reflect.Value) error {
+	key := pointerKey{ptr: iField.Pointer(), from: iField.Type(), to: vField.Type()}
+	if s.applying[key] {
+		return fmt.Errorf("cycle detected applying type '%v' to '%v'", iField.Type(), vField.Type())
+	}
+	s.applying[key] = true
+	defer delete(s.applying, key)
+
+	if vField.CanAddr() {
+		ptrKey := pointerKey{ptr: iField.Pointer(), from: iField.Type(), to: reflect.PtrTo(vField.Type())}
+		if _, ok := s.pointers[ptrKey]; !ok {
+			s.pointers[ptrKey] = vField.Addr()
+			err := s.applyField(iField.Elem(), vField)
+			if err != nil {
+				delete(s.pointers, ptrKey)
+			}
+			return err
+		}
+	}
+	return s.applyField(iField.Elem(), vField)
+}
+
+func mapApplier(s *state, iField reflect.Value, vField reflect.Value) (bool, error) {
 	if !iField.IsValid() || !vField.IsValid() {
 		return false, nil
 	}
@@ -288,16 +503,22 @@ func mapApplier(iField reflect.Value, vField reflect.Value) (bool, error) {
 	vKeyType := vField.Type().Key()
 	vElemType := vField.Type().Elem()
 
-	newMap := reflect.MakeMap(vField.Type())
+	newMap := s.targetMap(vField)
 
 	for _, key := range iField.MapKeys() {
+		if err := s.ctx.Err(); err != nil {
+			return false, err
+		}
 		newKey := reflect.New(vKeyType)
 		newElem := reflect.New(vElemType)
-		err := applyField(key, newKey.Elem())
+		err := s.applyField(key, newKey.Elem())
 		if err != nil {
 			return false, err
 		}
-		err = applyField(iField.MapIndex(key), newElem.Elem())
+		if existing := newMap.MapIndex(newKey.Elem()); existing.IsValid() {
+			newElem.Elem().Set(existing)
+		}
+		err = s.applyField(iField.MapIndex(key), newElem.Elem())
 		if err != nil {
 			return false, err
 		}
@@ -308,3 +529,385 @@ func mapApplier(iField reflect.Value, vField reflect.Value) (bool, error) {
 
 	return true, nil
 }
+
+// elemApplier applies the concrete value held by an interface, so values
+// decoded into interface{} maps and slices convert like any other value.
+// A nil interface leaves the target unchanged.
+func elemApplier(s *state, iField reflect.Value, vField reflect.Value) (bool, error) {
+	if !iField.IsValid() || !vField.IsValid() {
+		return false, nil
+	}
+	if iField.Type().Kind() != reflect.Interface {
+		return false, nil
+	}
+	if iField.IsNil() {
+		return true, nil
+	}
+	err := s.applyField(iField.Elem(), vField)
+	return err == nil, err
+}
+
+// mapStructApplier converts between structs and maps with string keys.
+// Map keys are matched against field names using the same rules as mapFields.
+func mapStructApplier(s *state, iField reflect.Value, vField reflect.Value) (bool, error) {
+	if !iField.IsValid() || !vField.IsValid() {
+		return false, nil
+	}
+	iKind := iField.Type().Kind()
+	vKind := vField.Type().Kind()
+	if iKind == reflect.Map && vKind == reflect.Struct && isStringMap(iField.Type()) {
+		err := s.mapToStruct(iField, vField)
+		return err == nil, err
+	}
+	if iKind == reflect.Struct && vKind == reflect.Map && isStringMap(vField.Type()) {
+		err := s.structToMap(iField, vField)
+		return err == nil, err
+	}
+	return false, nil
+}
+
+func (s *state) mapToStruct(iField reflect.Value, vField reflect.Value) error {
+	newPtr := reflect.New(vField.Type())
+	newPtr.Elem().Set(vField)
+	// Hooks receive a pointer to the map as the counterpart.
+	iPtr := reflect.New(iField.Type())
+	iPtr.Elem().Set(iField)
+	if err := s.beforeStructConvert(iPtr.Interface(), newPtr.Interface()); err != nil {
+		return err
+	}
+	iField = iPtr.Elem()
+
+	keyType := iField.Type().Key()
+	vFields := mapFields(newPtr.Interface(), reflect.Zero(iField.Type()).Interface())
+	s.exposeFields(vFields)
+	for name, field := range vFields {
+		value := iField.MapIndex(reflect.ValueOf(name).Convert(keyType))
+		if !value.IsValid() {
+			continue
+		}
+		if err := s.checkField(vField.Type(), field); err != nil {
+			return err
+		}
+		err := s.applyTagged(value, field.value, field.Tag)
+		if err != nil {
+			return fmt.Errorf("%v: %w", name, err)
+		}
+	}
+	if err := s.afterStructConvert(iPtr.Interface(), newPtr.Interface()); err != nil {
+		return err
+	}
+	vField.Set(newPtr.Elem())
+	return nil
+}
+
+func (s *state) structToMap(iField reflect.Value, vField reflect.Value) error {
+	keyType := vField.Type().Key()
+	elemType := vField.Type().Elem()
+
+	// Read fields from an addressable copy, so unexported fields can be exposed
+	// and hooks cannot modify the original.
+	src := reflect.New(iField.Type())
+	src.Elem().Set(iField)
+	// Hooks receive a pointer to the map as the counterpart.
+	mapPtr := reflect.New(vField.Type())
+	mapPtr.Elem().Set(s.targetMap(vField))
+	if err := s.beforeStructConvert(src.Interface(), mapPtr.Interface()); err != nil {
+		return err
+	}
+	newMap := mapPtr.Elem()
+	if newMap.IsNil() {
+		newMap = reflect.MakeMap(vField.Type())
+	}
+	iFields := mapFields(src.Interface(), reflect.Zero(vField.Type()).Interface())
+	s.exposeFields(iFields)
+	for name, f := range iFields {
+		if err := s.checkField(iField.Type(), f); err != nil {
+			return err
+		}
+		field := f.value
+		if !field.CanInterface() {
+			continue
+		}
+		newElem := reflect.New(elemType)
+		if existing := newMap.MapIndex(reflect.ValueOf(name).Convert(keyType)); existing.IsValid() {
+			newElem.Elem().Set(existing)
+		}
+		var err error
+		if nested := reflect.Indirect(field); isEmptyInterface(elemType) && nested.IsValid() && hasExportedFields(nested.Type()) {
+			// Nested structs held in interface values become nested maps.
+			// Structs without exported fields, such as time.Time, are kept as-is.
+			nestedMap := reflect.New(vField.Type())
+			if existing := newElem.Elem(); !existing.IsNil() && existing.Elem().Type() == vField.Type() {
+				nestedMap.Elem().Set(existing.Elem())
+			}
+			err = s.applyField(field, nestedMap.Elem())
+			if err == nil {
+				newElem.Elem().Set(nestedMap.Elem())
+			}
+		} else if isEmptyInterface(elemType) && holdsStructs(field.Type(), make(map[reflect.Type]bool)) {
+			// Slices and maps of structs become slices and maps of nested maps.
+			var values reflect.Value
+			values, err = s.nestedValues(field, vField.Type())
+			if err == nil {
+				newElem.Elem().Set(values)
+			}
+		} else {
+			err = s.applyTagged(field, newElem.Elem(), f.Tag)
+		}
+		if err != nil {
+			return fmt.Errorf("%v: %w", name, err)
+		}
+		newMap.SetMapIndex(reflect.ValueOf(name).Convert(keyType), newElem.Elem())
+	}
+	mapPtr.Elem().Set(newMap)
+	if err := s.afterStructConvert(src.Interface(), mapPtr.Interface()); err != nil {
+		return err
+	}
+	vField.Set(mapPtr.Elem())
+	return nil
+}
+
+func isStringMap(t reflect.Type) bool {
+	return t.Kind() == reflect.Map && t.Key().Kind() == reflect.String
+}
+
+func isStructOrMap(t reflect.Type) bool {
+	return t.Kind() == reflect.Struct || t.Kind() == reflect.Map
+}
+
+func isEmptyInterface(t reflect.Type) bool {
+	return t.Kind() == reflect.Interface && t.NumMethod() == 0
+}
+
+// nestedValues returns the slice, array or map v with its elements held in interface values,
+// and structs among them converted to maps of type mapType, as structToMap does for fields.
+// Nil slices and maps, and other values, are returned unchanged.
+func (s *state) nestedValues(v reflect.Value, mapType reflect.Type) (reflect.Value, error) {
+	elemType := mapType.Elem()
+	nested := func(elem reflect.Value) (reflect.Value, error) {
+		if inner := reflect.Indirect(elem); inner.IsValid() && hasExportedFields(inner.Type()) {
+			nestedMap := reflect.New(mapType)
+			err := s.applyField(elem, nestedMap.Elem())
+			return nestedMap.Elem(), err
+		}
+		return s.nestedValues(elem, mapType)
+	}
+
+	switch v.Kind() {
+	case reflect.Slice, reflect.Array:
+		if v.Kind() == reflect.Slice && v.IsNil() {
+			return v, nil
+		}
+		values := reflect.MakeSlice(reflect.SliceOf(elemType), v.Len(), v.Len())
+		for i := 0; i < v.Len(); i++ {
+			elem, err := nested(v.Index(i))
+			if err != nil {
+				return values, fmt.Errorf("%v: %w", i, err)
+			}
+			values.Index(i).Set(elem)
+		}
+		return values, nil
+	case reflect.Map:
+		if v.IsNil() {
+			return v, nil
+		}
+		valuesType := reflect.MapOf(v.Type().Key(), elemType)
+		if isStringMap(v.Type()) {
+			valuesType = mapType
+		}
+		values := reflect.MakeMapWithSize(valuesType, v.Len())
+		for _, key := range v.MapKeys() {
+			elem, err := nested(v.MapIndex(key))
+			if err != nil {
+				return values, fmt.Errorf("%v: %w", key, err)
+			}
+			values.SetMapIndex(key.Convert(valuesType.Key()), elem)
+		}
+		return values, nil
+	}
+	return v, nil
+}
+
+// holdsStructs reports whether t is a slice, array or map holding structs with exported fields,
+// directly or through pointers and other slices, arrays and maps.
+func holdsStructs(t reflect.Type, visited map[reflect.Type]bool) bool {
+	if visited[t] {
+		return false
+	}
+	visited[t] = true
+	switch t.Kind() {
+	case reflect.Slice, reflect.Array, reflect.Map:
+		elem := indirectType(t.Elem())
+		return hasExportedFields(elem) || holdsStructs(elem, visited)
+	}
+	return false
+}
+
+func hasExportedFields(t reflect.Type) bool {
+	if t.Kind() != reflect.Struct {
+		return false
+	}
+	for i := 0; i < t.NumField(); i++ {
+		if t.Field(i).PkgPath == "" {
+			return true
+		}
+	}
+	return false
+}
+
+// targetMap returns the map that entries should be applied to for vField.
+// This is a new map unless maps are being merged and vField already holds one.
+func (s *state) targetMap(vField reflect.Value) reflect.Value {
+	if s.mergeMaps && !vField.IsNil() {
+		return vField
+	}
+	return reflect.MakeMap(vField.Type())
+}
+
+// concrete returns the value held by v if v is a non-nil interface.
+func concrete(v reflect.Value) reflect.Value {
+	if v.Kind() == reflect.Interface && !v.IsNil() {
+		return v.Elem()
+	}
+	return v
+}
+
+// deepCopyApplier recursively clones values of identical types when deep copying,
+// so the target never shares slices, maps or pointers with the source.
+func deepCopyApplier(s *state, iField reflect.Value, vField reflect.Value) (bool, error) {
+	if !s.deepCopy || !iField.IsValid() || !vField.IsValid() {
+		return false, nil
+	}
+	if iField.Type() != vField.Type() || !isReference(iField.Type(), s.unexportedFields) {
+		return false, nil
+	}
+	if s.fieldwise(iField, vField) {
+		// Leave these to be applied element by element so transforms are called
+		// and maps are merged, which also copies them.
+		return false, nil
+	}
+
+	switch iField.Kind() {
+	case reflect.Ptr:
+		if iField.IsNil() {
+			vField.Set(iField)
+			return true, nil
+		}
+		err := s.applyPointer(iField, vField)
+		if err != nil {
+			return false, err
+		}
+	case reflect.Interface:
+		if iField.IsNil() {
+			vField.Set(iField)
+			return true, nil
+		}
+		newValue := reflect.New(iField.Elem().Type())
+		err := s.applyField(iField.Elem(), newValue.Elem())
+		if err != nil {
+			return false, err
+		}
+		vField.Set(newValue.Elem())
+	case reflect.Slice:
+		if iField.IsNil() {
+			vField.Set(iField)
+			return true, nil
+		}
+		key := pointerKey{ptr: iField.Pointer(), len: iField.Len(), from: iField.Type(), to: vField.Type()}
+		if target, ok := s.pointers[key]; ok {
+			vField.Set(target)
+			return true, nil
+		}
+		newSlice := reflect.MakeSlice(iField.Type(), iField.Len(), iField.Len())
+		if iField.Len() > 0 {
+			// Slices that contain themselves refer to the copy.
+			s.pointers[key] = newSlice
+		}
+		for i := 0; i < iField.Len(); i++ {
+			if err := s.ctx.Err(); err != nil {
+				return false, err
+			}
+			err := s.applyField(iField.Index(i), newSlice.Index(i))
+			if err != nil {
+				return false, err
+			}
+		}
+		vField.Set(newSlice)
+	case reflect.Array:
+		for i := 0; i < iField.Len(); i++ {
+			err := s.applyField(iField.Index(i), vField.Index(i))
+			if err != nil {
+				return false, err
+			}
+		}
+	case reflect.Map:
+		if iField.IsNil() {
+			vField.Set(iField)
+			return true, nil
+		}
+		mapKey := pointerKey{ptr: iField.Pointer(), from: iField.Type(), to: vField.Type()}
+		if target, ok := s.pointers[mapKey]; ok {
+			vField.Set(target)
+			return true, nil
+		}
+		newMap := reflect.MakeMapWithSize(iField.Type(), iField.Len())
+		// Maps that contain themselves refer to the copy.
+		s.pointers[mapKey] = newMap
+		for _, key := range iField.MapKeys() {
+			if err := s.ctx.Err(); err != nil {
+				return false, err
+			}
+			newKey := reflect.New(iField.Type().Key())
+			newElem := reflect.New(iField.Type().Elem())
+			err := s.applyField(key, newKey.Elem())
+			if err != nil {
+				return false, err
+			}
+			err = s.applyField(iField.MapIndex(key), newElem.Elem())
+			if err != nil {
+				return false, err
+			}
+			newMap.SetMapIndex(newKey.Elem(), newElem.Elem())
+		}
+		vField.Set(newMap)
+	case reflect.Struct:
+		// Copy the whole struct first so unexported fields are kept,
+		// then replace each exported field with a deep copy.
+		// Unexported fields are also replaced if they are being applied.
+		src := reflect.New(iField.Type()).Elem()
+		src.Set(iField)
+		vField.Set(iField)
+		for i := 0; i < iField.NumField(); i++ {
+			iValue, vValue := src.Field(i), vField.Field(i)
+			if iField.Type().Field(i).PkgPath != "" {
+				if !s.unexportedFields {
+					continue
+				}
+				iValue, vValue = expose(iValue), expose(vValue)
+			}
+			err := s.applyField(iValue, vValue)
+			if err != nil {
+				return false, fmt.Errorf("%v: %w", iField.Type().Field(i).Name, err)
+			}
+		}
+	}
+	return true, nil
+}
+
+// isReference reports whether values of t may share memory when copied,
+// considering unexported struct fields if unexported is set.
+func isReference(t reflect.Type, unexported bool) bool {
+	switch t.Kind() {
+	case reflect.Ptr, reflect.Interface, reflect.Slice, reflect.Map:
+		return true
+	case reflect.Array:
+		return isReference(t.Elem(), unexported)
+	case reflect.Struct:
+		for i := 0; i < t.NumField(); i++ {
+			if (t.Field(i).PkgPath == "" || unexported) && isReference(t.Field(i).Type, unexported) {
+				return true
+			}
+		}
+	}
+	return false
+}
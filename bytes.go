@@ -0,0 +1,94 @@
+package struct2struct
+
+import (
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"reflect"
+)
+
+var (
+	bytesType = reflect.TypeOf([]byte(nil))
+	runesType = reflect.TypeOf([]rune(nil))
+)
+
+const (
+	encodingBase64    = "base64"
+	encodingBase64URL = "base64url"
+	encodingHex       = "hex"
+)
+
+// bytesApplier converts between strings and byte or rune slices.
+// Byte slices hold the bytes of the string, unless the field tags select an
+// encoding with `s2s:"base64"`, `s2s:"base64url"` or `s2s:"hex"`.
+// Rune slices hold the Unicode code points of the string.
+func bytesApplier(s *state, iField reflect.Value, vField reflect.Value) (bool, error) {
+	if !iField.IsValid() || !vField.IsValid() {
+		return false, nil
+	}
+
+	switch {
+	case isBytes(iField.Type()) && vField.Kind() == reflect.String:
+		vField.SetString(s.encodeBytes(iField.Bytes()))
+	case isRunes(iField.Type()) && vField.Kind() == reflect.String:
+		runes := make([]rune, iField.Len())
+		for k := range runes {
+			runes[k] = rune(iField.Index(k).Int())
+		}
+		vField.SetString(string(runes))
+	case iField.Kind() == reflect.String && isBytes(vField.Type()):
+		b, err := s.decodeBytes(iField.String())
+		if err != nil {
+			return false, err
+		}
+		vField.Set(reflect.ValueOf(b).Convert(vField.Type()))
+	case iField.Kind() == reflect.String && isRunes(vField.Type()):
+		vField.Set(reflect.ValueOf([]rune(iField.String())).Convert(vField.Type()))
+	default:
+		return false, nil
+	}
+	return true, nil
+}
+
+func (s *state) encodeBytes(b []byte) string {
+	switch s.options.encoding {
+	case encodingBase64:
+		return base64.StdEncoding.EncodeToString(b)
+	case encodingBase64URL:
+		return base64.URLEncoding.EncodeToString(b)
+	case encodingHex:
+		return hex.EncodeToString(b)
+	}
+	return string(b)
+}
+
+func (s *state) decodeBytes(str string) ([]byte, error) {
+	var (
+		b   []byte
+		err error
+	)
+	switch s.options.encoding {
+	case encodingBase64:
+		b, err = base64.StdEncoding.DecodeString(str)
+	case encodingBase64URL:
+		b, err = base64.URLEncoding.DecodeString(str)
+	case encodingHex:
+		b, err = hex.DecodeString(str)
+	default:
+		return []byte(str), nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("invalid %v: %w", s.options.encoding, err)
+	}
+	return b, nil
+}
+
+// isBytes reports whether t is a slice of bytes.
+func isBytes(t reflect.Type) bool {
+	return t.Kind() == reflect.Slice && t.Elem() == bytesType.Elem()
+}
+
+// isRunes reports whether t is a slice of runes.
+func isRunes(t reflect.Type) bool {
+	return t.Kind() == reflect.Slice && t.Elem() == runesType.Elem()
+}
@@ -0,0 +1,138 @@
+package struct2struct_test
+
+import (
+	"errors"
+	"testing"
+)
+
+type rawMessage []byte
+
+func TestMarshalBytes(t *testing.T) {
+	var tests = []marshalTest{
+		{
+			name:     "Bytes to string",
+			in:       []byte("hi"),
+			other:    stringPtr(""),
+			expected: stringPtr("hi"),
+		},
+		{
+			name:     "String to bytes",
+			in:       "hi",
+			other:    &[]byte{},
+			expected: &[]byte{'h', 'i'},
+		},
+		{
+			name:     "Named bytes to string",
+			in:       rawMessage(`{"a":1}`),
+			other:    stringPtr(""),
+			expected: stringPtr(`{"a":1}`),
+		},
+		{
+			name:     "String to named bytes",
+			in:       `{"a":1}`,
+			other:    &rawMessage{},
+			expected: &rawMessage{'{', '"', 'a', '"', ':', '1', '}'},
+		},
+		{
+			name:     "Runes to string",
+			in:       []rune("héllo"),
+			other:    stringPtr(""),
+			expected: stringPtr("héllo"),
+		},
+		{
+			name:     "String to runes",
+			in:       "héllo",
+			other:    &[]rune{},
+			expected: &[]rune{'h', 'é', 'l', 'l', 'o'},
+		},
+		{
+			name: "Encoded fields",
+			in: struct {
+				Base64    []byte `s2s:"base64"`
+				Base64URL []byte `s2s:"base64url"`
+				Hex       []byte
+				Raw       []byte
+			}{
+				Base64:    []byte{0xfb, 0xff},
+				Base64URL: []byte{0xfb, 0xff},
+				Hex:       []byte{0xfb, 0xff},
+				Raw:       []byte("raw"),
+			},
+			other: &struct {
+				Base64    string
+				Base64URL string
+				Hex       string `s2s:"hex"`
+				Raw       string
+			}{},
+			expected: &struct {
+				Base64    string
+				Base64URL string
+				Hex       string `s2s:"hex"`
+				Raw       string
+			}{
+				Base64:    "+/8=",
+				Base64URL: "-_8=",
+				Hex:       "fbff",
+				Raw:       "raw",
+			},
+		},
+		{
+			name: "Decoded fields",
+			in: struct {
+				Base64 string
+				Hex    []string
+			}{
+				Base64: "+/8=",
+				Hex:    []string{"fbff", "00"},
+			},
+			other: &struct {
+				Base64 []byte   `s2s:"base64"`
+				Hex    [][]byte `s2s:"hex"`
+			}{},
+			expected: &struct {
+				Base64 []byte   `s2s:"base64"`
+				Hex    [][]byte `s2s:"hex"`
+			}{
+				Base64: []byte{0xfb, 0xff},
+				Hex:    [][]byte{{0xfb, 0xff}, {0x00}},
+			},
+		},
+		{
+			name: "Map to encoded field",
+			in: map[string]interface{}{
+				"Data": "aGk=",
+			},
+			other: &struct {
+				Data []byte `s2s:"base64"`
+			}{},
+			expected: &struct {
+				Data []byte `s2s:"base64"`
+			}{
+				Data: []byte("hi"),
+			},
+		},
+		{
+			name: "Invalid encoding",
+			in: struct {
+				Data string
+			}{
+				Data: "xyz",
+			},
+			other: &struct {
+				Data []byte `s2s:"hex"`
+			}{},
+			err: errors.New("Data: invalid hex: encoding/hex: invalid byte: U+0078 'x'"),
+		},
+		{
+			name: "Unknown tag option",
+			in: struct {
+				Data []byte `s2s:"base32"`
+			}{},
+			other: &struct {
+				Data string
+			}{},
+			err: errors.New("Data: unknown s2s tag option 'base32'"),
+		},
+	}
+	executeTests(t, tests)
+}
@@ -0,0 +1,75 @@
+package struct2struct
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+)
+
+// ImaginaryError is returned when applying a complex number with a non-zero
+// imaginary part to a type that can only hold real numbers.
+type ImaginaryError struct {
+	Value complex128
+	Type  reflect.Type
+}
+
+func (e *ImaginaryError) Error() string {
+	return fmt.Sprintf("cannot apply complex value '%v' with an imaginary part to '%v'", e.Value, e.Type)
+}
+
+// complexApplier applies complex numbers, real numbers and strings to complex numbers.
+// Strings are parsed with strconv.ParseComplex.
+func complexApplier(s *state, iField reflect.Value, vField reflect.Value) (bool, error) {
+	if !iField.IsValid() || !vField.IsValid() {
+		return false, nil
+	}
+
+	var bitSize = 64
+	switch vField.Type().Kind() {
+	case reflect.Complex64:
+	case reflect.Complex128:
+		bitSize = 128
+	default:
+		return false, nil
+	}
+
+	var value complex128
+
+	switch iField.Type().Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		value = complex(float64(iField.Int()), 0)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		value = complex(float64(iField.Uint()), 0)
+	case reflect.Float32, reflect.Float64:
+		value = complex(iField.Float(), 0)
+	case reflect.Complex64:
+		value, _ = strconv.ParseComplex(fmt.Sprint(complex64(iField.Complex())), bitSize)
+	case reflect.Complex128:
+		value = iField.Complex()
+	case reflect.String:
+		var err error
+		value, err = strconv.ParseComplex(s.numberText(iField.String()), bitSize)
+		if err != nil {
+			return false, err
+		}
+	default:
+		return false, nil
+	}
+
+	vField.SetComplex(value)
+	return true, nil
+}
+
+// realPart returns the real part of the complex number v,
+// or an *ImaginaryError if v cannot be applied to the real number type t.
+func realPart(v reflect.Value, t reflect.Type) (float64, error) {
+	c := v.Complex()
+	if imag(c) != 0 {
+		return 0, &ImaginaryError{Value: c, Type: t}
+	}
+	return real(c), nil
+}
+
+func isComplex(t reflect.Type) bool {
+	return t.Kind() == reflect.Complex64 || t.Kind() == reflect.Complex128
+}
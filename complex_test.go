@@ -0,0 +1,84 @@
+package struct2struct_test
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestMarshalComplex(t *testing.T) {
+	var tests = []marshalTest{
+		{
+			name:     "complex64 to complex128",
+			in:       []complex64{complex(1.1, -2.5)},
+			other:    &[]complex128{},
+			expected: &[]complex128{complex(1.1, -2.5)},
+		},
+		{
+			name:     "complex128 to complex64",
+			in:       []complex128{complex(1.5, 2)},
+			other:    &[]complex64{},
+			expected: &[]complex64{complex(1.5, 2)},
+		},
+		{
+			name:     "Real numbers to complex",
+			in:       []interface{}{1, uint8(2), 3.5},
+			other:    &[]complex128{},
+			expected: &[]complex128{complex(1, 0), complex(2, 0), complex(3.5, 0)},
+		},
+		{
+			name:     "String to complex",
+			in:       []string{"(1+2i)", " 3.5 ", "-2i"},
+			other:    &[]complex128{},
+			expected: &[]complex128{complex(1, 2), complex(3.5, 0), complex(0, -2)},
+		},
+		{
+			name:  "Invalid string to complex",
+			in:    []string{"abc"},
+			other: &[]complex64{},
+			err:   errors.New("strconv.ParseComplex: parsing \"abc\": invalid syntax"),
+		},
+		{
+			name:     "Complex to string",
+			in:       []complex128{complex(1, 2)},
+			other:    &[]string{},
+			expected: &[]string{"(1+2i)"},
+		},
+		{
+			name:     "Real complex to numbers",
+			in:       []complex128{complex(2, 0)},
+			other:    &[]int8{},
+			expected: &[]int8{2},
+		},
+		{
+			name:     "Real complex to uint",
+			in:       []complex64{complex(3, 0)},
+			other:    &[]uint{},
+			expected: &[]uint{3},
+		},
+		{
+			name:     "Real complex to float",
+			in:       []complex128{complex(2.5, 0)},
+			other:    &[]float64{},
+			expected: &[]float64{2.5},
+		},
+		{
+			name:  "Imaginary complex to int",
+			in:    []complex128{complex(1, 2)},
+			other: &[]int{},
+			err:   errors.New("cannot apply complex value '(1+2i)' with an imaginary part to 'int'"),
+		},
+		{
+			name: "Imaginary complex to float field",
+			in: struct {
+				Value complex64
+			}{
+				Value: complex(1, -1),
+			},
+			other: &struct {
+				Value float32
+			}{},
+			err: errors.New("Value: cannot apply complex value '(1-1i)' with an imaginary part to 'float32'"),
+		},
+	}
+	executeTests(t, tests)
+}
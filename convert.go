@@ -0,0 +1,47 @@
+package struct2struct
+
+// Convert applies src to a new value of type Dst.
+func Convert[Dst any](src interface{}, opts ...Option) (Dst, error) {
+	return ConvertWith[Dst](New(opts...), src)
+}
+
+// ConvertWith applies src to a new value of type Dst using the Converter c.
+func ConvertWith[Dst any](c *Converter, src interface{}) (Dst, error) {
+	var dst Dst
+	err := c.Marshal(src, &dst)
+	return dst, err
+}
+
+// ConvertSlice applies each element of src to a new element of type Dst.
+// A nil slice converts to a nil slice.
+func ConvertSlice[Src any, Dst any](src []Src, opts ...Option) ([]Dst, error) {
+	return ConvertSliceWith[Src, Dst](New(opts...), src)
+}
+
+// ConvertSliceWith applies each element of src to a new element of type Dst using the Converter c.
+// A nil slice converts to a nil slice.
+func ConvertSliceWith[Src any, Dst any](c *Converter, src []Src) ([]Dst, error) {
+	if src == nil {
+		return nil, nil
+	}
+	dst := make([]Dst, 0, len(src))
+	err := c.Marshal(src, &dst)
+	return dst, err
+}
+
+// ConvertMap applies each value of src to a new value of type Dst, keeping the same keys.
+// A nil map converts to a nil map.
+func ConvertMap[K comparable, Src any, Dst any](src map[K]Src, opts ...Option) (map[K]Dst, error) {
+	return ConvertMapWith[K, Src, Dst](New(opts...), src)
+}
+
+// ConvertMapWith applies each value of src to a new value of type Dst using the Converter c,
+// keeping the same keys. A nil map converts to a nil map.
+func ConvertMapWith[K comparable, Src any, Dst any](c *Converter, src map[K]Src) (map[K]Dst, error) {
+	if src == nil {
+		return nil, nil
+	}
+	var dst map[K]Dst
+	err := c.Marshal(src, &dst)
+	return dst, err
+}
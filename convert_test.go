@@ -0,0 +1,83 @@
+package struct2struct_test
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/theothertomelliott/struct2struct"
+)
+
+func TestConvert(t *testing.T) {
+	out, err := struct2struct.Convert[TwoIntsB](TwoIntsA{First: 10, Second: 20})
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := TwoIntsB{First: 10, SecondB: 20}
+	if !reflect.DeepEqual(expected, out) {
+		t.Errorf("values did not match, expected '%v', got '%v'", expected, out)
+	}
+
+	ptr, err := struct2struct.Convert[*TwoIntsB](&TwoIntsA{First: 10, Second: 20})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(&expected, ptr) {
+		t.Errorf("values did not match, expected '%v', got '%v'", &expected, ptr)
+	}
+
+	_, err = struct2struct.Convert[int]("abc")
+	expectedErr := errors.New("strconv.ParseInt: parsing \"abc\": invalid syntax")
+	if err == nil || err.Error() != expectedErr.Error() {
+		t.Errorf("errors did not match, expected '%v', got '%v'", expectedErr, err)
+	}
+}
+
+func TestConvertSlice(t *testing.T) {
+	out, err := struct2struct.ConvertSlice[TwoIntsA, TwoIntsB]([]TwoIntsA{{First: 1, Second: 2}, {First: 3, Second: 4}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := []TwoIntsB{{First: 1, SecondB: 2}, {First: 3, SecondB: 4}}
+	if !reflect.DeepEqual(expected, out) {
+		t.Errorf("values did not match, expected '%v', got '%v'", expected, out)
+	}
+
+	empty, err := struct2struct.ConvertSlice[TwoIntsA, TwoIntsB]([]TwoIntsA{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if empty == nil || len(empty) != 0 {
+		t.Errorf("expected an empty slice, got '%#v'", empty)
+	}
+
+	none, err := struct2struct.ConvertSlice[TwoIntsA, TwoIntsB](nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if none != nil {
+		t.Errorf("expected a nil slice, got '%#v'", none)
+	}
+}
+
+func TestConvertMap(t *testing.T) {
+	out, err := struct2struct.ConvertMap[string, string, int](map[string]string{"a": "1", "b": "2"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := map[string]int{"a": 1, "b": 2}
+	if !reflect.DeepEqual(expected, out) {
+		t.Errorf("values did not match, expected '%v', got '%v'", expected, out)
+	}
+
+	c := struct2struct.New(struct2struct.DeepCopy())
+	nested := map[int][]string{1: {"a"}}
+	copied, err := struct2struct.ConvertMapWith[int, []string, []string](c, nested)
+	if err != nil {
+		t.Fatal(err)
+	}
+	nested[1][0] = "changed"
+	if copied[1][0] != "a" {
+		t.Errorf("expected a deep copy, got '%v'", copied)
+	}
+}
@@ -0,0 +1,118 @@
+package struct2struct
+
+import (
+	"context"
+	"errors"
+	"reflect"
+)
+
+// Converter applies values from one type to another according to a set of options.
+// A Converter may be reused for any number of calls to Marshal.
+type Converter struct {
+	mergeMaps bool
+	deepCopy  bool
+	maxDepth  int
+
+	funcPolicy       FuncPolicy
+	unexportedFields bool
+	strictUnexported bool
+
+	numberPrefixes   bool
+	groupSeparator   rune
+	decimalSeparator rune
+	floatFormat      byte
+	floatPrecision   int
+	integerBase      int
+
+	strictEnums bool
+	enumNames   map[reflect.Type]map[interface{}]string
+	enumValues  map[reflect.Type]map[string]reflect.Value
+	enumPairs   map[typePair]map[interface{}]reflect.Value
+
+	transforms map[fieldKey]FieldTransform
+	mappings   map[typePair]*Mapping
+}
+
+// New creates a Converter configured with the provided options.
+func New(opts ...Option) *Converter {
+	c := &Converter{}
+	for _, opt := range opts {
+		opt(c)
+	}
+	return c
+}
+
+// Marshal processes i and applies its values to v using the options of c.
+// Fields are matched first by s2s tags, then by field names.
+func (c *Converter) Marshal(i interface{}, v interface{}) error {
+	return c.MarshalContext(context.Background(), i, v)
+}
+
+// MarshalContext behaves like Marshal, but stops with the context's error if ctx is
+// cancelled while slices and maps are being applied. The context is available to
+// conversion hooks.
+func (c *Converter) MarshalContext(ctx context.Context, i interface{}, v interface{}) error {
+	if v == nil {
+		return errors.New("nil target")
+	}
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	if reflect.TypeOf(v).Kind() == reflect.Ptr {
+		return c.apply(ctx, reflect.ValueOf(i), reflect.ValueOf(v).Elem())
+	}
+	return errors.New("expect target to be a pointer")
+}
+
+// apply applies iField to vField in a new state, starting the goroutines forwarding
+// adapted channels once it succeeds.
+func (c *Converter) apply(ctx context.Context, iField reflect.Value, vField reflect.Value) error {
+	s := c.newState(ctx)
+	if err := s.applyField(iField, vField); err != nil {
+		return err
+	}
+	for _, forward := range s.forwarders {
+		go forward()
+	}
+	return nil
+}
+
+func (c *Converter) newState(ctx context.Context) *state {
+	return &state{
+		Converter: c,
+		ctx:       ctx,
+		pointers:  make(map[pointerKey]reflect.Value),
+		applying:  make(map[pointerKey]bool),
+	}
+}
+
+// state tracks a single call to Marshal.
+type state struct {
+	*Converter
+
+	ctx context.Context
+
+	// pointers maps source pointers, slices and maps to the target values created for them.
+	pointers map[pointerKey]reflect.Value
+	// applying holds source pointers being dereferenced into non-pointer targets.
+	applying map[pointerKey]bool
+	// depth is the number of nested values currently being applied.
+	depth int
+	// fields holds the struct fields currently being applied, outermost first.
+	fields []fieldFrame
+	// transformed caches whether types contain fields with registered transforms.
+	transformed map[reflect.Type]bool
+	// options holds the tag options of the struct fields being applied.
+	options tagOptions
+	// forwarders feed adapted channels, and are started once the value has been applied.
+	forwarders []func()
+}
+
+// pointerKey identifies a source pointer being applied to a target type.
+type pointerKey struct {
+	ptr uintptr
+	// len distinguishes slices sharing the same array.
+	len  int
+	from reflect.Type
+	to   reflect.Type
+}
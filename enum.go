@@ -0,0 +1,110 @@
+package struct2struct
+
+import (
+	"fmt"
+	"reflect"
+)
+
+// RegisterEnum registers the names of values of the enum type E with c.
+// Values of E applied to strings use their names, and strings naming a value
+// are applied to E as that value. Other values are converted as normal
+// unless StrictEnums is set. Enums should be registered before c is used.
+func RegisterEnum[E comparable](c *Converter, names map[E]string) {
+	t := reflect.TypeOf((*E)(nil)).Elem()
+	if c.enumNames == nil {
+		c.enumNames = make(map[reflect.Type]map[interface{}]string)
+		c.enumValues = make(map[reflect.Type]map[string]reflect.Value)
+	}
+	c.enumNames[t] = make(map[interface{}]string)
+	c.enumValues[t] = make(map[string]reflect.Value)
+	for value, name := range names {
+		c.enumNames[t][value] = name
+		c.enumValues[t][name] = reflect.ValueOf(value)
+	}
+}
+
+// RegisterEnumPair registers how values of the enum type A correspond to values of the enum type B,
+// for conversions in both directions. Other values are converted as normal unless StrictEnums is set.
+// Enums should be registered before c is used.
+func RegisterEnumPair[A comparable, B comparable](c *Converter, pairs map[A]B) {
+	a := reflect.TypeOf((*A)(nil)).Elem()
+	b := reflect.TypeOf((*B)(nil)).Elem()
+	if c.enumPairs == nil {
+		c.enumPairs = make(map[typePair]map[interface{}]reflect.Value)
+	}
+	forward := make(map[interface{}]reflect.Value)
+	backward := make(map[interface{}]reflect.Value)
+	for from, to := range pairs {
+		forward[from] = reflect.ValueOf(to)
+		backward[to] = reflect.ValueOf(from)
+	}
+	c.enumPairs[typePair{src: a, dst: b}] = forward
+	c.enumPairs[typePair{src: b, dst: a}] = backward
+}
+
+// EnumError is returned for values missing from a registered enum when StrictEnums is set.
+type EnumError struct {
+	Type  reflect.Type
+	Value interface{}
+}
+
+func (e *EnumError) Error() string {
+	return fmt.Sprintf("unknown value '%v' for enum '%v'", e.Value, e.Type)
+}
+
+// enumApplier converts values of registered enums by name, or between registered pairs of enums.
+func enumApplier(s *state, iField reflect.Value, vField reflect.Value) (bool, error) {
+	if !iField.IsValid() || !vField.IsValid() || !iField.CanInterface() {
+		return false, nil
+	}
+	if len(s.enumPairs) == 0 && len(s.enumNames) == 0 {
+		return false, nil
+	}
+	iType := iField.Type()
+	vType := vField.Type()
+
+	if pairs, ok := s.enumPairs[typePair{src: iType, dst: vType}]; ok {
+		if value, ok := pairs[iField.Interface()]; ok {
+			vField.Set(value)
+			return true, nil
+		}
+		return false, s.unknownEnum(iType, iField.Interface())
+	}
+
+	if names, ok := s.enumNames[iType]; ok && vType.Kind() == reflect.String {
+		if name, ok := names[iField.Interface()]; ok {
+			vField.SetString(name)
+			return true, nil
+		}
+		return false, s.unknownEnum(iType, iField.Interface())
+	}
+
+	if values, ok := s.enumValues[vType]; ok && iType.Kind() == reflect.String {
+		if value, ok := values[iField.String()]; ok {
+			vField.Set(value)
+			return true, nil
+		}
+		return false, s.unknownEnum(vType, iField.String())
+	}
+	return false, nil
+}
+
+// unknownEnum returns an error for a value missing from the enum t if enums are strict.
+func (s *state) unknownEnum(t reflect.Type, value interface{}) error {
+	if !s.strictEnums {
+		return nil
+	}
+	return &EnumError{Type: t, Value: value}
+}
+
+// isEnum reports whether values of type src are converted to dst using registered enums.
+func (c *Converter) isEnum(src reflect.Type, dst reflect.Type) bool {
+	if _, ok := c.enumPairs[typePair{src: src, dst: dst}]; ok {
+		return true
+	}
+	if _, ok := c.enumNames[src]; ok && dst.Kind() == reflect.String {
+		return true
+	}
+	_, ok := c.enumValues[dst]
+	return ok && src.Kind() == reflect.String
+}
@@ -0,0 +1,126 @@
+package struct2struct_test
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/theothertomelliott/struct2struct"
+)
+
+type protoStatus int32
+
+const (
+	protoStatusUnknown protoStatus = 0
+	protoStatusActive  protoStatus = 3
+)
+
+type domainStatus string
+
+const (
+	domainStatusUnknown domainStatus = "unknown"
+	domainStatusActive  domainStatus = "active"
+)
+
+func TestEnumNames(t *testing.T) {
+	c := struct2struct.New()
+	struct2struct.RegisterEnum(c, map[protoStatus]string{
+		protoStatusUnknown: "UNKNOWN",
+		protoStatusActive:  "ACTIVE",
+	})
+
+	var names []string
+	if err := c.Marshal([]protoStatus{protoStatusActive, 7}, &names); err != nil {
+		t.Fatal(err)
+	}
+	if expected := []string{"ACTIVE", "7"}; !reflect.DeepEqual(expected, names) {
+		t.Errorf("values did not match, expected '%v', got '%v'", expected, names)
+	}
+
+	var values []protoStatus
+	if err := c.Marshal([]string{"ACTIVE", "UNKNOWN", "7"}, &values); err != nil {
+		t.Fatal(err)
+	}
+	if expected := []protoStatus{protoStatusActive, protoStatusUnknown, 7}; !reflect.DeepEqual(expected, values) {
+		t.Errorf("values did not match, expected '%v', got '%v'", expected, values)
+	}
+}
+
+func TestEnumPairs(t *testing.T) {
+	c := struct2struct.New()
+	struct2struct.RegisterEnumPair(c, map[protoStatus]domainStatus{
+		protoStatusUnknown: domainStatusUnknown,
+		protoStatusActive:  domainStatusActive,
+	})
+
+	var in = struct {
+		Status protoStatus
+	}{
+		Status: protoStatusActive,
+	}
+	var out struct {
+		Status domainStatus
+	}
+	if err := c.Marshal(in, &out); err != nil {
+		t.Fatal(err)
+	}
+	if out.Status != domainStatusActive {
+		t.Errorf("expected '%v', got '%v'", domainStatusActive, out.Status)
+	}
+
+	in.Status = 0
+	if err := c.Marshal(out, &in); err != nil {
+		t.Fatal(err)
+	}
+	if in.Status != protoStatusActive {
+		t.Errorf("expected '%v', got '%v'", protoStatusActive, in.Status)
+	}
+}
+
+func TestStrictEnums(t *testing.T) {
+	c := struct2struct.New(struct2struct.StrictEnums())
+	struct2struct.RegisterEnumPair(c, map[protoStatus]domainStatus{
+		protoStatusActive: domainStatusActive,
+	})
+	struct2struct.RegisterEnum(c, map[protoStatus]string{
+		protoStatusActive: "ACTIVE",
+	})
+
+	var tests = []struct {
+		name string
+		in   interface{}
+		out  interface{}
+		err  string
+	}{
+		{
+			name: "Unknown pair",
+			in:   protoStatus(7),
+			out:  new(domainStatus),
+			err:  "unknown value '7' for enum 'struct2struct_test.protoStatus'",
+		},
+		{
+			name: "Unknown value",
+			in:   protoStatus(7),
+			out:  new(string),
+			err:  "unknown value '7' for enum 'struct2struct_test.protoStatus'",
+		},
+		{
+			name: "Unknown name",
+			in:   "INACTIVE",
+			out:  new(protoStatus),
+			err:  "unknown value 'INACTIVE' for enum 'struct2struct_test.protoStatus'",
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			err := c.Marshal(test.in, test.out)
+			var enumErr *struct2struct.EnumError
+			if !errors.As(err, &enumErr) {
+				t.Fatalf("expected an EnumError, got '%v'", err)
+			}
+			if err.Error() != test.err {
+				t.Errorf("errors did not match, expected '%v', got '%v'", test.err, err)
+			}
+		})
+	}
+}
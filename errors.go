@@ -0,0 +1,12 @@
+package struct2struct
+
+import "fmt"
+
+// DepthError is returned when a value is nested more deeply than permitted by MaxDepth.
+type DepthError struct {
+	Limit int
+}
+
+func (e *DepthError) Error() string {
+	return fmt.Sprintf("maximum depth of %d exceeded", e.Limit)
+}
@@ -0,0 +1,185 @@
+package struct2struct
+
+import (
+	"bytes"
+	"fmt"
+	"reflect"
+	"strings"
+	"text/tabwriter"
+)
+
+// Explanation describes how each field of a target struct type would be set from a source struct type.
+type Explanation struct {
+	Src    string             `json:"src"`
+	Dst    string             `json:"dst"`
+	Fields []FieldExplanation `json:"fields"`
+	// UnmatchedSources lists source fields that would not be applied to any target field.
+	UnmatchedSources []string `json:"unmatchedSources,omitempty"`
+}
+
+// FieldExplanation describes how a single target field would be set.
+type FieldExplanation struct {
+	Target string `json:"target"`
+	// Sources lists the source fields that set the target, empty if the target is unmatched.
+	Sources []string `json:"sources,omitempty"`
+	// SourceRule and TargetRule describe how the source and target fields were matched.
+	SourceRule MatchRule `json:"sourceRule,omitempty"`
+	TargetRule MatchRule `json:"targetRule,omitempty"`
+	// Applier names the applier that would convert the value, such as "struct" or "int".
+	Applier string `json:"applier,omitempty"`
+	// Error describes why the field cannot be converted, if it cannot.
+	Error string `json:"error,omitempty"`
+}
+
+// Explain describes how the fields of the struct type src would be applied to the struct type dst,
+// without converting any values.
+func Explain(src reflect.Type, dst reflect.Type, opts ...Option) (*Explanation, error) {
+	return New(opts...).Explain(src, dst)
+}
+
+// Explain describes how the fields of the struct type src would be applied to the struct type dst
+// using the transforms and mappings registered with c.
+func (c *Converter) Explain(src reflect.Type, dst reflect.Type) (*Explanation, error) {
+	src = indirectType(src)
+	dst = indirectType(dst)
+	if src.Kind() != reflect.Struct || dst.Kind() != reflect.Struct {
+		return nil, fmt.Errorf("cannot explain type '%v' to '%v', both must be structs", src, dst)
+	}
+
+	m := c.mappings[typePair{src: src, dst: dst}]
+	srcFields := matchFields(src, dst)
+	dstFields := matchFields(dst, src)
+	m.remap(srcFields, dstFields)
+
+	explanation := &Explanation{
+		Src: src.String(),
+		Dst: dst.String(),
+	}
+	explained := make(map[string]*FieldExplanation)
+	// promoted lists targets of mappings that are promoted from embedded structs, in order.
+	var promoted []string
+	target := func(name string) *FieldExplanation {
+		if field, ok := explained[name]; ok {
+			return field
+		}
+		field := &FieldExplanation{Target: name}
+		explained[name] = field
+		if dstField, ok := dst.FieldByName(name); ok && len(dstField.Index) > 1 {
+			promoted = append(promoted, name)
+		}
+		return field
+	}
+	matched := make(map[string]bool)
+	for name, dstField := range dstFields {
+		field := &FieldExplanation{
+			Target:     dstField.Name,
+			TargetRule: dstField.rule,
+		}
+		explained[dstField.Name] = field
+
+		srcField, ok := srcFields[name]
+		if !ok {
+			continue
+		}
+		matched[srcField.Name] = true
+		field.Sources = []string{srcField.Name}
+		field.SourceRule = srcField.rule
+		switch {
+		case dstField.PkgPath != "" && !c.unexportedFields:
+			field.Applier = "settableTest"
+			field.Error = "unexported fields are not set"
+		case srcField.PkgPath != "" && !c.unexportedFields:
+			field.Error = "unexported fields are not read"
+		case c.transforms[fieldKey{typ: src, path: srcField.Name}] != nil:
+			field.Applier = "transform"
+		default:
+			v := &validator{Converter: c, visiting: make(map[typePair]bool)}
+			if opts, err := parseTags(srcField.Tag, dstField.Tag); err != nil {
+				v.fail("", srcField.Type, dstField.Type, "%v", err)
+			} else {
+				v.options = opts
+				field.Applier = v.check("", srcField.Type, dstField.Type)
+			}
+			var errs []string
+			for _, err := range v.fields {
+				errs = append(errs, err.Error())
+			}
+			field.Error = strings.Join(errs, "; ")
+		}
+	}
+
+	if m != nil {
+		for _, computed := range m.computed {
+			field := target(computed.dst)
+			field.Sources = computed.srcs
+			field.SourceRule = MatchMapping
+			field.Applier = "compute"
+			field.Error = ""
+			for _, src := range computed.srcs {
+				matched[src] = true
+			}
+		}
+		for _, spread := range m.spread {
+			for _, dstName := range spread.dsts {
+				field := target(dstName)
+				field.Sources = []string{spread.src}
+				field.SourceRule = MatchMapping
+				field.Applier = "spread"
+				field.Error = ""
+			}
+			matched[spread.src] = true
+		}
+	}
+
+	for i := 0; i < dst.NumField(); i++ {
+		if field, ok := explained[dst.Field(i).Name]; ok {
+			explanation.Fields = append(explanation.Fields, *field)
+			continue
+		}
+		// Another field matched the same name first.
+		explanation.Fields = append(explanation.Fields, FieldExplanation{Target: dst.Field(i).Name})
+	}
+	for _, name := range promoted {
+		explanation.Fields = append(explanation.Fields, *explained[name])
+	}
+	for i := 0; i < src.NumField(); i++ {
+		if name := src.Field(i).Name; !matched[name] {
+			explanation.UnmatchedSources = append(explanation.UnmatchedSources, name)
+		}
+	}
+	return explanation, nil
+}
+
+// String formats e as a table with a row for each target field.
+func (e *Explanation) String() string {
+	var buf bytes.Buffer
+	fmt.Fprintf(&buf, "%v -> %v\n", e.Src, e.Dst)
+	w := tabwriter.NewWriter(&buf, 0, 4, 2, ' ', 0)
+	fmt.Fprintln(w, "TARGET\tSOURCE\tSOURCE RULE\tTARGET RULE\tAPPLIER\tERROR")
+	for _, field := range e.Fields {
+		sources := strings.Join(field.Sources, ", ")
+		if sources == "" {
+			sources = "-"
+		}
+		fmt.Fprintf(w, "%v\t%v\t%v\t%v\t%v\t%v\n",
+			field.Target,
+			sources,
+			orDash(string(field.SourceRule)),
+			orDash(string(field.TargetRule)),
+			orDash(field.Applier),
+			orDash(field.Error),
+		)
+	}
+	w.Flush()
+	if len(e.UnmatchedSources) > 0 {
+		fmt.Fprintf(&buf, "unmatched source fields: %v\n", strings.Join(e.UnmatchedSources, ", "))
+	}
+	return buf.String()
+}
+
+func orDash(s string) string {
+	if s == "" {
+		return "-"
+	}
+	return s
+}
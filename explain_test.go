@@ -0,0 +1,168 @@
+package struct2struct_test
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/theothertomelliott/struct2struct"
+)
+
+type explainSource struct {
+	First  int
+	Second int `explainTarget:"SecondB"`
+	Street string
+	City   string
+	Extra  bool
+}
+
+type explainTarget struct {
+	SecondB int
+	First   string
+	Address string
+	Missing string
+	hidden  int
+}
+
+func TestExplain(t *testing.T) {
+	c := struct2struct.New()
+	err := c.Register(struct2struct.MappingFor(explainSource{}, explainTarget{}).
+		Compute("Address", []string{"Street", "City"}, func(ctx context.Context, values ...interface{}) (interface{}, error) {
+			return nil, nil
+		}))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	explanation, err := c.Explain(reflect.TypeOf(explainSource{}), reflect.TypeOf(&explainTarget{}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := &struct2struct.Explanation{
+		Src: "struct2struct_test.explainSource",
+		Dst: "struct2struct_test.explainTarget",
+		Fields: []struct2struct.FieldExplanation{
+			{
+				Target:     "SecondB",
+				Sources:    []string{"Second"},
+				SourceRule: struct2struct.MatchTypeNameTag,
+				TargetRule: struct2struct.MatchFieldName,
+				Applier:    "matchedType",
+			},
+			{
+				Target:     "First",
+				Sources:    []string{"First"},
+				SourceRule: struct2struct.MatchFieldName,
+				TargetRule: struct2struct.MatchFieldName,
+				Applier:    "string",
+			},
+			{
+				Target:     "Address",
+				Sources:    []string{"Street", "City"},
+				SourceRule: struct2struct.MatchMapping,
+				TargetRule: struct2struct.MatchFieldName,
+				Applier:    "compute",
+			},
+			{
+				Target:     "Missing",
+				TargetRule: struct2struct.MatchFieldName,
+			},
+			{
+				Target:     "hidden",
+				TargetRule: struct2struct.MatchFieldName,
+			},
+		},
+		UnmatchedSources: []string{"Extra"},
+	}
+	if !reflect.DeepEqual(expected, explanation) {
+		t.Errorf("values did not match, expected '%+v', got '%+v'", expected, explanation)
+	}
+
+	expectedString := `struct2struct_test.explainSource -> struct2struct_test.explainTarget
+TARGET   SOURCE        SOURCE RULE    TARGET RULE  APPLIER      ERROR
+SecondB  Second        type name tag  field name   matchedType  -
+First    First         field name     field name   string       -
+Address  Street, City  mapping        field name   compute      -
+Missing  -             -              field name   -            -
+hidden   -             -              field name   -            -
+unmatched source fields: Extra
+`
+	if explanation.String() != expectedString {
+		t.Errorf("strings did not match, expected:\n%v\ngot:\n%v", expectedString, explanation.String())
+	}
+}
+
+func TestExplainErrors(t *testing.T) {
+	explanation, err := struct2struct.Explain(reflect.TypeOf(struct{ First bool }{}), reflect.TypeOf(TwoIntsB{}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if field := explanation.Fields[1]; field.Error != "could not apply type 'bool' to 'int'" {
+		t.Errorf("expected an error for field First, got '%+v'", field)
+	}
+
+	_, err = struct2struct.Explain(reflect.TypeOf(""), reflect.TypeOf(TwoIntsB{}))
+	expected := "cannot explain type 'string' to 'struct2struct_test.TwoIntsB', both must be structs"
+	if err == nil || err.Error() != expected {
+		t.Errorf("errors did not match, expected '%v', got '%v'", expected, err)
+	}
+}
+
+type explainShadowSource struct {
+	B string
+	C string
+}
+
+type ExplainEmbedded struct {
+	Inner string
+}
+
+type explainShadowTarget struct {
+	A string
+	X string `explainShadowSource:"A"`
+	ExplainEmbedded
+}
+
+func TestExplainShadowedAndPromotedTargets(t *testing.T) {
+	c := struct2struct.New()
+	err := c.Register(struct2struct.MappingFor(explainShadowSource{}, explainShadowTarget{}).
+		Compute("A", []string{"B"}, func(ctx context.Context, values ...interface{}) (interface{}, error) {
+			return nil, nil
+		}).
+		Spread("C", []string{"Inner"}, func(ctx context.Context, value interface{}) ([]interface{}, error) {
+			return nil, nil
+		}))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	explanation, err := c.Explain(reflect.TypeOf(explainShadowSource{}), reflect.TypeOf(explainShadowTarget{}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := []struct2struct.FieldExplanation{
+		{
+			Target:     "A",
+			Sources:    []string{"B"},
+			SourceRule: struct2struct.MatchMapping,
+			Applier:    "compute",
+		},
+		{
+			Target:     "X",
+			TargetRule: struct2struct.MatchTypeNameTag,
+		},
+		{
+			Target:     "ExplainEmbedded",
+			TargetRule: struct2struct.MatchFieldName,
+		},
+		{
+			Target:     "Inner",
+			Sources:    []string{"C"},
+			SourceRule: struct2struct.MatchMapping,
+			Applier:    "spread",
+		},
+	}
+	if !reflect.DeepEqual(expected, explanation.Fields) {
+		t.Errorf("values did not match, expected '%+v', got '%+v'", expected, explanation.Fields)
+	}
+}
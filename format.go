@@ -0,0 +1,150 @@
+package struct2struct
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+	"time"
+)
+
+var (
+	timeType     = reflect.TypeOf(time.Time{})
+	durationType = reflect.TypeOf(time.Duration(0))
+)
+
+// durationUnits are the units a `format` tag may select for durations.
+var durationUnits = map[string]time.Duration{
+	"ns": time.Nanosecond,
+	"us": time.Microsecond,
+	"µs": time.Microsecond,
+	"ms": time.Millisecond,
+	"s":  time.Second,
+	"m":  time.Minute,
+	"h":  time.Hour,
+}
+
+// formatApplier formats numbers, times and durations as strings, and parses times and durations from strings.
+// A `format` tag on either field selects how values are formatted:
+//   - for numbers, a fmt verb such as "%.2f" or "%x", used only when formatting
+//   - for times, a layout such as "2006-01-02"
+//   - for durations, a unit such as "ms" or "h", with the string holding a number of that unit
+//
+// Without a tag, numbers are formatted as set by FloatFormat and IntegerBase and durations are
+// parsed with time.ParseDuration, with strings that are not durations, such as "1000", left to be
+// parsed as a number of nanoseconds. Other values are left to the remaining appliers.
+func formatApplier(s *state, iField reflect.Value, vField reflect.Value) (bool, error) {
+	if !iField.IsValid() || !vField.IsValid() || !iField.CanInterface() {
+		return false, nil
+	}
+	format := s.options.format
+
+	switch {
+	case iField.Type() == timeType && vField.Kind() == reflect.String && format != "":
+		vField.SetString(iField.Interface().(time.Time).Format(format))
+	case iField.Kind() == reflect.String && vField.Type() == timeType && format != "":
+		t, err := time.Parse(format, iField.String())
+		if err != nil {
+			return false, err
+		}
+		vField.Set(reflect.ValueOf(t))
+	case iField.Type() == durationType && vField.Kind() == reflect.String && format != "":
+		unit, err := durationUnit(format)
+		if err != nil {
+			return false, err
+		}
+		d := iField.Interface().(time.Duration)
+		vField.SetString(strconv.FormatFloat(float64(d)/float64(unit), 'f', -1, 64))
+	case iField.Kind() == reflect.String && vField.Type() == durationType:
+		d, err := s.parseDuration(iField.String(), format)
+		if err != nil && format == "" {
+			return false, nil
+		}
+		if err != nil {
+			return false, err
+		}
+		vField.SetInt(int64(d))
+	case isNumber(iField.Type()) && vField.Kind() == reflect.String:
+		str, ok := s.formatNumber(iField, format)
+		if !ok {
+			return false, nil
+		}
+		vField.SetString(str)
+	default:
+		return false, nil
+	}
+	return true, nil
+}
+
+// formats reports whether formatApplier would apply values of type src to dst with the given format.
+func (c *Converter) formats(src reflect.Type, dst reflect.Type, format string) bool {
+	switch {
+	case src == timeType && dst.Kind() == reflect.String, src.Kind() == reflect.String && dst == timeType,
+		src == durationType && dst.Kind() == reflect.String:
+		return format != ""
+	case src.Kind() == reflect.String && dst == durationType:
+		return true
+	case isNumber(src) && dst.Kind() == reflect.String:
+		if format != "" {
+			return true
+		}
+		if isTextMarshaler(src) || implements(src, stringerType) {
+			return false
+		}
+		switch numberApplier(src) {
+		case "int", "uint":
+			return c.integerBase != 0
+		}
+		return c.floatFormat != 0
+	}
+	return false
+}
+
+// formatNumber formats v with the fmt verb format, or the number formats of the converter.
+// It returns false if v should be formatted as normal.
+func (s *state) formatNumber(v reflect.Value, format string) (string, bool) {
+	if format != "" {
+		return fmt.Sprintf(format, v.Interface()), true
+	}
+	if isTextMarshaler(v.Type()) || implements(v.Type(), stringerType) {
+		return "", false
+	}
+	switch numberApplier(v.Type()) {
+	case "int":
+		if s.integerBase != 0 {
+			return strconv.FormatInt(v.Int(), s.integerBase), true
+		}
+	case "uint":
+		if s.integerBase != 0 {
+			return strconv.FormatUint(v.Uint(), s.integerBase), true
+		}
+	case "float":
+		if s.floatFormat != 0 {
+			return strconv.FormatFloat(v.Float(), s.floatFormat, s.floatPrecision, v.Type().Bits()), true
+		}
+	}
+	return "", false
+}
+
+// parseDuration parses str as a number of the unit format, or with time.ParseDuration if format is empty.
+func (s *state) parseDuration(str string, format string) (time.Duration, error) {
+	if format == "" {
+		return time.ParseDuration(s.numberText(str))
+	}
+	unit, err := durationUnit(format)
+	if err != nil {
+		return 0, err
+	}
+	f, err := s.parseFloat(str, 64)
+	if err != nil {
+		return 0, err
+	}
+	return time.Duration(f * float64(unit)), nil
+}
+
+func durationUnit(format string) (time.Duration, error) {
+	unit, ok := durationUnits[format]
+	if !ok {
+		return 0, fmt.Errorf("unknown duration unit '%v'", format)
+	}
+	return unit, nil
+}
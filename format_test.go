@@ -0,0 +1,238 @@
+package struct2struct_test
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/theothertomelliott/struct2struct"
+)
+
+func TestMarshalFormat(t *testing.T) {
+	var tests = []marshalTest{
+		{
+			name:     "Default float",
+			in:       []float64{1e6},
+			other:    &[]string{},
+			expected: &[]string{"1e+06"},
+		},
+		{
+			name:     "Float format option",
+			in:       []float64{1e6, 0.125},
+			other:    &[]string{},
+			expected: &[]string{"1000000.00", "0.12"},
+			opts:     []struct2struct.Option{struct2struct.FloatFormat('f', 2)},
+		},
+		{
+			name:     "Float32 format option",
+			in:       []float32{1.1},
+			other:    &[]string{},
+			expected: &[]string{"1.1"},
+			opts:     []struct2struct.Option{struct2struct.FloatFormat('f', -1)},
+		},
+		{
+			name:     "Integer base option",
+			in:       []interface{}{255, uint8(10), -16},
+			other:    &[]string{},
+			expected: &[]string{"ff", "a", "-10"},
+			opts:     []struct2struct.Option{struct2struct.IntegerBase(16)},
+		},
+		{
+			name:     "Integer base option out of range",
+			in:       []interface{}{255, uint8(10), -16},
+			other:    &[]string{},
+			expected: &[]string{"255", "10", "-16"},
+			opts:     []struct2struct.Option{struct2struct.IntegerBase(1)},
+		},
+		{
+			name:     "Integer base option with stringer",
+			in:       []time.Month{time.March},
+			other:    &[]string{},
+			expected: &[]string{"March"},
+			opts:     []struct2struct.Option{struct2struct.IntegerBase(16)},
+		},
+		{
+			name: "Number format tags",
+			in: struct {
+				Price float64 `format:"%.2f"`
+				Flags int     `format:"%08b"`
+				Plain float64
+			}{
+				Price: 3.14159,
+				Flags: 5,
+				Plain: 1e6,
+			},
+			other: &struct {
+				Price string
+				Flags string
+				Plain string
+			}{},
+			expected: &struct {
+				Price string
+				Flags string
+				Plain string
+			}{
+				Price: "3.14",
+				Flags: "00000101",
+				Plain: "1000000",
+			},
+			opts: []struct2struct.Option{struct2struct.FloatFormat('f', -1)},
+		},
+		{
+			name: "Time and duration to string",
+			in: struct {
+				Date    time.Time
+				Created time.Time
+				Timeout time.Duration
+				Delay   time.Duration
+			}{
+				Date:    time.Date(2020, 1, 2, 3, 4, 5, 0, time.UTC),
+				Created: time.Date(2020, 1, 2, 3, 4, 5, 0, time.UTC),
+				Timeout: 1500 * time.Millisecond,
+				Delay:   90 * time.Minute,
+			},
+			other: &struct {
+				Date    string `format:"2006-01-02"`
+				Created string
+				Timeout string `format:"s"`
+				Delay   string
+			}{},
+			expected: &struct {
+				Date    string `format:"2006-01-02"`
+				Created string
+				Timeout string `format:"s"`
+				Delay   string
+			}{
+				Date:    "2020-01-02",
+				Created: "2020-01-02T03:04:05Z",
+				Timeout: "1.5",
+				Delay:   "1h30m0s",
+			},
+		},
+		{
+			name: "String to time and duration",
+			in: struct {
+				Date    string
+				Timeout string
+				Delay   string
+			}{
+				Date:    "2020-01-02",
+				Timeout: "250",
+				Delay:   "1h30m",
+			},
+			other: &struct {
+				Date    time.Time     `format:"2006-01-02"`
+				Timeout time.Duration `format:"ms"`
+				Delay   time.Duration
+			}{},
+			expected: &struct {
+				Date    time.Time     `format:"2006-01-02"`
+				Timeout time.Duration `format:"ms"`
+				Delay   time.Duration
+			}{
+				Date:    time.Date(2020, 1, 2, 0, 0, 0, 0, time.UTC),
+				Timeout: 250 * time.Millisecond,
+				Delay:   90 * time.Minute,
+			},
+		},
+		{
+			name: "Integer string to duration",
+			in: struct {
+				Timeout string
+			}{
+				Timeout: "1000",
+			},
+			other: &struct {
+				Timeout time.Duration
+			}{},
+			expected: &struct {
+				Timeout time.Duration
+			}{
+				Timeout: 1000,
+			},
+		},
+		{
+			name: "Map to struct with duration",
+			in: map[string]string{
+				"Timeout": "1000",
+				"Delay":   "1h30m",
+			},
+			other: &struct {
+				Timeout time.Duration
+				Delay   time.Duration
+			}{},
+			expected: &struct {
+				Timeout time.Duration
+				Delay   time.Duration
+			}{
+				Timeout: 1000,
+				Delay:   90 * time.Minute,
+			},
+		},
+		{
+			name: "Invalid duration",
+			in: struct {
+				Timeout string
+			}{
+				Timeout: "soon",
+			},
+			other: &struct {
+				Timeout time.Duration
+			}{},
+			err: errors.New("Timeout: strconv.ParseInt: parsing \"soon\": invalid syntax"),
+		},
+		{
+			name: "Invalid time",
+			in: struct {
+				Date string
+			}{
+				Date: "01/02/2020",
+			},
+			other: &struct {
+				Date time.Time `format:"2006-01-02"`
+			}{},
+			err: errors.New("Date: parsing time \"01/02/2020\" as \"2006-01-02\": cannot parse \"01/02/2020\" as \"2006\""),
+		},
+		{
+			name: "Unknown duration unit",
+			in: struct {
+				Timeout time.Duration `format:"days"`
+			}{},
+			other: &struct {
+				Timeout string
+			}{},
+			err: errors.New("Timeout: unknown duration unit 'days'"),
+		},
+	}
+	executeTests(t, tests)
+}
+
+func TestExplainFormat(t *testing.T) {
+	src := reflect.TypeOf(struct {
+		Date    time.Time
+		Timeout string
+		Delay   time.Duration
+		Count   int
+		Unit    time.Duration
+	}{})
+	dst := reflect.TypeOf(struct {
+		Date    string `format:"2006-01-02"`
+		Timeout time.Duration
+		Delay   string `format:"ms"`
+		Count   string `format:"%03d"`
+		Unit    string `format:"days"`
+	}{})
+	explanation, err := struct2struct.Explain(src, dst)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, field := range explanation.Fields {
+		if field.Applier != "format" {
+			t.Errorf("expected field %v to use the format applier, got '%v'", field.Target, field.Applier)
+		}
+	}
+	if field := explanation.Fields[4]; field.Error != "unknown duration unit 'days'" {
+		t.Errorf("expected an error for field Unit, got '%+v'", field)
+	}
+}
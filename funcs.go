@@ -0,0 +1,148 @@
+package struct2struct
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+)
+
+// FuncPolicy controls how funcs and channels are applied to types they cannot be assigned to.
+type FuncPolicy int
+
+const (
+	// FuncError returns an error, stopping the conversion. This is the default.
+	FuncError FuncPolicy = iota
+	// FuncSkip leaves the target unchanged.
+	FuncSkip
+	// FuncAdapt wraps funcs and channels so their values are converted when used.
+	// Adapted funcs convert their arguments to the parameter types of the source func,
+	// and its results to the result types of the target, panicking if a value cannot be converted.
+	// Adapted channels are new channels of the target type, fed by a goroutine that converts each
+	// value received from the source channel until it is closed, a value cannot be converted or
+	// the context passed to MarshalContext is cancelled, after which the target channel is closed.
+	// The goroutine is only started once Marshal succeeds, so a failed conversion leaves the source
+	// channel unread. Funcs with different numbers of parameters or results cannot be adapted,
+	// and channels are only adapted if both the source and target can be received from.
+	FuncAdapt
+)
+
+// funcApplier applies funcs and channels that are assignable to the target,
+// and applies the FuncPolicy of the converter to funcs and channels that are not.
+func funcApplier(s *state, iField reflect.Value, vField reflect.Value) (bool, error) {
+	if !iField.IsValid() || !vField.IsValid() || iField.Kind() == reflect.Interface {
+		return false, nil
+	}
+	if !isFuncOrChan(iField.Type()) && !isFuncOrChan(vField.Type()) {
+		return false, nil
+	}
+	if iField.Type().AssignableTo(vField.Type()) {
+		vField.Set(iField)
+		return true, nil
+	}
+
+	switch s.funcPolicy {
+	case FuncSkip:
+		return true, nil
+	case FuncAdapt:
+		if iField.Kind() != vField.Kind() || !iField.CanInterface() {
+			return false, nil
+		}
+		if err := canAdapt(iField.Type(), vField.Type()); err != nil {
+			return false, err
+		}
+		if iField.IsNil() {
+			vField.Set(reflect.Zero(vField.Type()))
+			return true, nil
+		}
+		if iField.Kind() == reflect.Func {
+			// Adapted funcs are called after Marshal returns, so are not cancelled with its context.
+			vField.Set(s.adaptFunc(context.WithoutCancel(s.ctx), iField, vField.Type()))
+		} else {
+			vField.Set(s.adaptChan(s.ctx, iField, vField.Type()))
+		}
+		return true, nil
+	}
+	return false, nil
+}
+
+// canAdapt returns an error if values of type src cannot be adapted to dst.
+func canAdapt(src reflect.Type, dst reflect.Type) error {
+	switch src.Kind() {
+	case reflect.Func:
+		if src.NumIn() != dst.NumIn() || src.NumOut() != dst.NumOut() || src.IsVariadic() != dst.IsVariadic() {
+			return fmt.Errorf("cannot adapt type '%v' to '%v', parameters and results do not match", src, dst)
+		}
+	case reflect.Chan:
+		if src.ChanDir()&reflect.RecvDir == 0 {
+			return fmt.Errorf("cannot adapt type '%v' to '%v', source cannot be received from", src, dst)
+		}
+		if dst.ChanDir()&reflect.RecvDir == 0 {
+			return fmt.Errorf("cannot adapt type '%v' to '%v', target cannot be received from", src, dst)
+		}
+	}
+	return nil
+}
+
+// adaptFunc returns a func of type t that calls fn, converting its arguments and results.
+func (s *state) adaptFunc(ctx context.Context, fn reflect.Value, t reflect.Type) reflect.Value {
+	c := s.Converter
+	return reflect.MakeFunc(t, func(args []reflect.Value) []reflect.Value {
+		in := make([]reflect.Value, len(args))
+		for k, arg := range args {
+			in[k] = reflect.New(fn.Type().In(k)).Elem()
+			if err := c.apply(ctx, arg, in[k]); err != nil {
+				panic(fmt.Errorf("argument %d: %w", k, err))
+			}
+		}
+		var out []reflect.Value
+		if fn.Type().IsVariadic() {
+			out = fn.CallSlice(in)
+		} else {
+			out = fn.Call(in)
+		}
+		results := make([]reflect.Value, len(out))
+		for k, value := range out {
+			results[k] = reflect.New(t.Out(k)).Elem()
+			if err := c.apply(ctx, value, results[k]); err != nil {
+				panic(fmt.Errorf("result %d: %w", k, err))
+			}
+		}
+		return results
+	})
+}
+
+// adaptChan returns a channel of type t, forwarding converted values received from ch
+// until ctx is cancelled. Forwarding starts once the value being applied by s succeeds.
+func (s *state) adaptChan(ctx context.Context, ch reflect.Value, t reflect.Type) reflect.Value {
+	c := s.Converter
+	out := reflect.MakeChan(reflect.ChanOf(reflect.BothDir, t.Elem()), ch.Cap())
+	done := reflect.ValueOf(ctx.Done())
+	s.forwarders = append(s.forwarders, func() {
+		defer out.Close()
+		for {
+			chosen, value, ok := reflect.Select([]reflect.SelectCase{
+				{Dir: reflect.SelectRecv, Chan: done},
+				{Dir: reflect.SelectRecv, Chan: ch},
+			})
+			if chosen == 0 || !ok {
+				return
+			}
+			converted := reflect.New(t.Elem()).Elem()
+			if err := c.apply(ctx, value, converted); err != nil {
+				return
+			}
+			chosen, _, _ = reflect.Select([]reflect.SelectCase{
+				{Dir: reflect.SelectRecv, Chan: done},
+				{Dir: reflect.SelectSend, Chan: out, Send: converted},
+			})
+			if chosen == 0 {
+				return
+			}
+		}
+	})
+	return out.Convert(t)
+}
+
+func isFuncOrChan(t reflect.Type) bool {
+	return t.Kind() == reflect.Func || t.Kind() == reflect.Chan
+}
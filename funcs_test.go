@@ -0,0 +1,195 @@
+package struct2struct_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/theothertomelliott/struct2struct"
+)
+
+type callbackSource struct {
+	Name     string
+	Callback func() int
+	Format   func(int, ...string) string
+	Events   chan int
+}
+
+type callbackTarget struct {
+	Name     string
+	Callback func() int64
+	Format   func(int64, ...string) string
+	Events   <-chan string
+}
+
+func TestMarshalFuncPolicy(t *testing.T) {
+	var in = callbackSource{
+		Name:     "name",
+		Callback: func() int { return 1 },
+		Format: func(n int, s ...string) string {
+			return fmt.Sprintf("%d %v", n, strings.Join(s, ","))
+		},
+	}
+
+	var tests = []marshalTest{
+		{
+			name: "Error by default",
+			in: struct {
+				Callback func() int
+			}{
+				Callback: in.Callback,
+			},
+			other: &struct {
+				Callback func() int64
+			}{},
+			err: errors.New("Callback: could not apply type 'func() int' to 'func() int64'"),
+		},
+		{
+			name:  "Skip",
+			in:    in,
+			other: &callbackTarget{},
+			expected: &callbackTarget{
+				Name: "name",
+			},
+			opts: []struct2struct.Option{struct2struct.FuncFields(struct2struct.FuncSkip)},
+		},
+		{
+			name:  "Adapt",
+			in:    in,
+			other: &callbackTarget{},
+			comparator: func(e interface{}, g interface{}) (bool, string) {
+				got := g.(*callbackTarget)
+				if got.Name != "name" {
+					return false, fmt.Sprintf("unexpected name '%v'", got.Name)
+				}
+				if n := got.Callback(); n != 1 {
+					return false, fmt.Sprintf("unexpected callback result %d", n)
+				}
+				if s := got.Format(2, "a", "b"); s != "2 a,b" {
+					return false, fmt.Sprintf("unexpected format result '%v'", s)
+				}
+				if got.Events != nil {
+					return false, "expected nil channel"
+				}
+				return true, ""
+			},
+			opts: []struct2struct.Option{struct2struct.FuncFields(struct2struct.FuncAdapt)},
+		},
+		{
+			name:  "Adapt with mismatched parameters",
+			in:    func(int) int { return 0 },
+			other: new(func() int64),
+			err:   errors.New("cannot adapt type 'func(int) int' to 'func() int64', parameters and results do not match"),
+			opts:  []struct2struct.Option{struct2struct.FuncFields(struct2struct.FuncAdapt)},
+		},
+		{
+			name:  "Adapt send only channel",
+			in:    make(chan<- int),
+			other: new(chan string),
+			err:   errors.New("cannot adapt type 'chan<- int' to 'chan string', source cannot be received from"),
+			opts:  []struct2struct.Option{struct2struct.FuncFields(struct2struct.FuncAdapt)},
+		},
+		{
+			name:  "Adapt to send only channel",
+			in:    make(chan int),
+			other: new(chan<- int64),
+			err:   errors.New("cannot adapt type 'chan int' to 'chan<- int64', target cannot be received from"),
+			opts:  []struct2struct.Option{struct2struct.FuncFields(struct2struct.FuncAdapt)},
+		},
+		{
+			name:  "Assignable channel",
+			in:    make(chan int),
+			other: new(<-chan int),
+			comparator: func(e interface{}, g interface{}) (bool, string) {
+				if *(g.(*<-chan int)) == nil {
+					return false, "expected a channel"
+				}
+				return true, ""
+			},
+		},
+	}
+	executeTests(t, tests)
+}
+
+func TestMarshalAdaptChan(t *testing.T) {
+	events := make(chan int, 3)
+	events <- 1
+	events <- 2
+	close(events)
+
+	var out callbackTarget
+	err := struct2struct.Marshal(
+		callbackSource{Events: events},
+		&out,
+		struct2struct.FuncFields(struct2struct.FuncAdapt),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var received []string
+	for event := range out.Events {
+		received = append(received, event)
+	}
+	if expected := []string{"1", "2"}; !reflect.DeepEqual(expected, received) {
+		t.Errorf("values did not match, expected '%v', got '%v'", expected, received)
+	}
+}
+
+func TestMarshalAdaptFuncPanics(t *testing.T) {
+	var out func() int
+	err := struct2struct.Marshal(
+		func() string { return "abc" },
+		&out,
+		struct2struct.FuncFields(struct2struct.FuncAdapt),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func() {
+		if recover() == nil {
+			t.Error("expected a panic")
+		}
+	}()
+	out()
+}
+
+func TestMarshalAdaptChanFailure(t *testing.T) {
+	events := make(chan int, 1)
+	events <- 1
+
+	var out []chan string
+	err := struct2struct.Marshal(
+		[]interface{}{events, "invalid"},
+		&out,
+		struct2struct.FuncFields(struct2struct.FuncAdapt),
+	)
+	if err == nil {
+		t.Fatal("expected an error")
+	}
+	select {
+	case event := <-events:
+		if event != 1 {
+			t.Errorf("expected 1, got %v", event)
+		}
+	default:
+		t.Error("expected the source channel not to be read")
+	}
+}
+
+func TestMarshalAdaptChanCancel(t *testing.T) {
+	events := make(chan int)
+	ctx, cancel := context.WithCancel(context.Background())
+
+	var out <-chan string
+	err := struct2struct.New(struct2struct.FuncFields(struct2struct.FuncAdapt)).MarshalContext(ctx, events, &out)
+	if err != nil {
+		t.Fatal(err)
+	}
+	cancel()
+	for event := range out {
+		t.Errorf("expected no values, got '%v'", event)
+	}
+}
@@ -0,0 +1,254 @@
+package struct2struct
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+)
+
+// Mapping describes how fields of one struct type are applied to another
+// where this cannot be expressed with tags and field names alone.
+// A Mapping takes effect once registered with a Converter.
+type Mapping struct {
+	src reflect.Type
+	dst reflect.Type
+
+	fields   map[string]string
+	ignored  map[string]bool
+	computed []computedField
+	spread   []spreadField
+	converts []ConvertFunc
+}
+
+// ComputeFunc builds the value of a target field from the values of several source fields,
+// passed in the order the fields were named.
+type ComputeFunc func(ctx context.Context, values ...interface{}) (interface{}, error)
+
+// SpreadFunc builds the values of several target fields from the value of one source field,
+// returned in the order the fields were named.
+type SpreadFunc func(ctx context.Context, value interface{}) ([]interface{}, error)
+
+// ConvertFunc completes a conversion after all fields have been applied.
+// src is a pointer to a copy of the source struct, and dst is a pointer to the target struct.
+type ConvertFunc func(ctx context.Context, src interface{}, dst interface{}) error
+
+type computedField struct {
+	dst  string
+	srcs []string
+	fn   ComputeFunc
+}
+
+type spreadField struct {
+	src  string
+	dsts []string
+	fn   SpreadFunc
+}
+
+// typePair identifies conversion from one type to another.
+type typePair struct {
+	src reflect.Type
+	dst reflect.Type
+}
+
+// MappingFor creates an empty Mapping from the struct type of src to the struct type of dst.
+// Either value may be a pointer to the struct.
+func MappingFor(src interface{}, dst interface{}) *Mapping {
+	return &Mapping{
+		src: indirectType(reflect.TypeOf(src)),
+		dst: indirectType(reflect.TypeOf(dst)),
+	}
+}
+
+// Field applies the source field src to the target field dst, regardless of their names and tags.
+func (m *Mapping) Field(src string, dst string) *Mapping {
+	if m.fields == nil {
+		m.fields = make(map[string]string)
+	}
+	m.fields[src] = dst
+	return m
+}
+
+// Ignore prevents the source field src from being applied to any target field.
+func (m *Mapping) Ignore(src string) *Mapping {
+	if m.ignored == nil {
+		m.ignored = make(map[string]bool)
+	}
+	m.ignored[src] = true
+	return m
+}
+
+// Convert adds fn to be called after all other fields have been applied.
+func (m *Mapping) Convert(fn ConvertFunc) *Mapping {
+	m.converts = append(m.converts, fn)
+	return m
+}
+
+// Compute sets the target field dst from the source fields srcs using fn.
+func (m *Mapping) Compute(dst string, srcs []string, fn ComputeFunc) *Mapping {
+	m.computed = append(m.computed, computedField{dst: dst, srcs: srcs, fn: fn})
+	return m
+}
+
+// Spread sets the target fields dsts from the source field src using fn.
+func (m *Mapping) Spread(src string, dsts []string, fn SpreadFunc) *Mapping {
+	m.spread = append(m.spread, spreadField{src: src, dsts: dsts, fn: fn})
+	return m
+}
+
+// validate checks that every field named by m exists.
+func (m *Mapping) validate() error {
+	if m.src.Kind() != reflect.Struct || m.dst.Kind() != reflect.Struct {
+		return fmt.Errorf("cannot map type '%v' to '%v', both must be structs", m.src, m.dst)
+	}
+	for src, dst := range m.fields {
+		if err := hasFields(m.src, src); err != nil {
+			return err
+		}
+		if err := hasFields(m.dst, dst); err != nil {
+			return err
+		}
+	}
+	for src := range m.ignored {
+		if err := hasFields(m.src, src); err != nil {
+			return err
+		}
+	}
+	for _, computed := range m.computed {
+		if err := hasFields(m.src, computed.srcs...); err != nil {
+			return err
+		}
+		if err := hasFields(m.dst, computed.dst); err != nil {
+			return err
+		}
+	}
+	for _, spread := range m.spread {
+		if err := hasFields(m.src, spread.src); err != nil {
+			return err
+		}
+		if err := hasFields(m.dst, spread.dsts...); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func hasFields(t reflect.Type, names ...string) error {
+	for _, name := range names {
+		field, ok := t.FieldByName(name)
+		if !ok {
+			return fmt.Errorf("no field '%v' in '%v'", name, t)
+		}
+		if field.PkgPath != "" {
+			return fmt.Errorf("field '%v' in '%v' is unexported", name, t)
+		}
+	}
+	return nil
+}
+
+// Register adds m to the mappings used by c, replacing any earlier Mapping between the same types.
+// An error is returned if m names fields that do not exist.
+// A Mapping from a type to itself is applied field by field in place of copying values of that type.
+// Mappings should be registered before c is used.
+func (c *Converter) Register(m *Mapping) error {
+	if err := m.validate(); err != nil {
+		return err
+	}
+	if c.mappings == nil {
+		c.mappings = make(map[typePair]*Mapping)
+	}
+	c.mappings[typePair{src: m.src, dst: m.dst}] = m
+	return nil
+}
+
+// mapping returns the Mapping registered from the struct type of i to that of v, if any.
+func (s *state) mapping(i interface{}, v interface{}) *Mapping {
+	if len(s.mappings) == 0 {
+		return nil
+	}
+	return s.mappings[typePair{
+		src: indirectType(reflect.TypeOf(i)),
+		dst: indirectType(reflect.TypeOf(v)),
+	}]
+}
+
+// remap updates source fields matched by mapFields to reflect renamed and ignored fields.
+func (m *Mapping) remap(iFields map[string]structField, vFields map[string]structField) {
+	if m == nil {
+		return
+	}
+	renamed := make(map[string]structField)
+	for name, iField := range iFields {
+		if _, ok := m.fields[iField.Name]; ok {
+			renamed[iField.Name] = iField
+			delete(iFields, name)
+		} else if m.ignored[iField.Name] {
+			delete(iFields, name)
+		}
+	}
+	for name, vField := range vFields {
+		for src, dst := range m.fields {
+			if iField, ok := renamed[src]; ok && vField.Name == dst {
+				iField.rule = MatchMapping
+				iFields[name] = iField
+			}
+		}
+	}
+}
+
+// applyMapping applies computed and spread fields of m from the struct i to the struct v,
+// then calls any functions added with Convert.
+func (s *state) applyMapping(m *Mapping, i interface{}, v interface{}) error {
+	if m == nil {
+		return nil
+	}
+	iValue := reflect.Indirect(reflect.ValueOf(i))
+	vValue := reflect.Indirect(reflect.ValueOf(v))
+
+	for _, computed := range m.computed {
+		values := make([]interface{}, len(computed.srcs))
+		for n, name := range computed.srcs {
+			values[n] = iValue.FieldByName(name).Interface()
+		}
+		value, err := computed.fn(s.ctx, values...)
+		if err != nil {
+			return fmt.Errorf("%v: %w", computed.dst, err)
+		}
+		if err := s.applyValue(value, vValue.FieldByName(computed.dst)); err != nil {
+			return fmt.Errorf("%v: %w", computed.dst, err)
+		}
+	}
+
+	for _, spread := range m.spread {
+		values, err := spread.fn(s.ctx, iValue.FieldByName(spread.src).Interface())
+		if err != nil {
+			return fmt.Errorf("%v: %w", spread.src, err)
+		}
+		if len(values) != len(spread.dsts) {
+			return fmt.Errorf("%v: expected %d values, got %d", spread.src, len(spread.dsts), len(values))
+		}
+		for n, name := range spread.dsts {
+			if err := s.applyValue(values[n], vValue.FieldByName(name)); err != nil {
+				return fmt.Errorf("%v: %w", name, err)
+			}
+		}
+	}
+
+	for _, convert := range m.converts {
+		if err := convert(s.ctx, i, v); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// applyValue applies a value returned by a user function to vField.
+// A nil value sets vField to its zero value.
+func (s *state) applyValue(value interface{}, vField reflect.Value) error {
+	if value == nil {
+		if vField.CanSet() {
+			vField.Set(reflect.Zero(vField.Type()))
+		}
+		return nil
+	}
+	return s.applyField(reflect.ValueOf(value), vField)
+}
@@ -0,0 +1,108 @@
+package struct2struct_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/theothertomelliott/struct2struct"
+)
+
+type flatAddress struct {
+	Name   string
+	Street string
+	City   string
+	Zip    string
+}
+
+type structuredAddress struct {
+	Name    string
+	Address string
+	First   string
+	Last    string
+}
+
+func TestMappingComputeAndSpread(t *testing.T) {
+	c := struct2struct.New()
+	err := c.Register(struct2struct.MappingFor(flatAddress{}, &structuredAddress{}).
+		Compute("Address", []string{"Street", "City", "Zip"}, func(ctx context.Context, values ...interface{}) (interface{}, error) {
+			return fmt.Sprintf("%v, %v %v", values...), nil
+		}).
+		Spread("Name", []string{"First", "Last"}, func(ctx context.Context, value interface{}) ([]interface{}, error) {
+			parts := strings.SplitN(value.(string), " ", 2)
+			return []interface{}{parts[0], parts[1]}, nil
+		}))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	in := []flatAddress{
+		{
+			Name:   "Jane Doe",
+			Street: "1 Main St",
+			City:   "Springfield",
+			Zip:    "12345",
+		},
+	}
+	var out []structuredAddress
+	if err := c.Marshal(in, &out); err != nil {
+		t.Fatal(err)
+	}
+	expected := []structuredAddress{
+		{
+			Name:    "Jane Doe",
+			Address: "1 Main St, Springfield 12345",
+			First:   "Jane",
+			Last:    "Doe",
+		},
+	}
+	if !reflect.DeepEqual(expected, out) {
+		t.Errorf("values did not match, expected '%v', got '%v'", expected, out)
+	}
+}
+
+func TestMappingErrors(t *testing.T) {
+	c := struct2struct.New()
+	err := c.Register(struct2struct.MappingFor(flatAddress{}, structuredAddress{}).
+		Compute("Missing", []string{"Street"}, nil))
+	expected := "no field 'Missing' in 'struct2struct_test.structuredAddress'"
+	if err == nil || err.Error() != expected {
+		t.Errorf("errors did not match, expected '%v', got '%v'", expected, err)
+	}
+
+	err = c.Register(struct2struct.MappingFor(flatAddress{}, "string"))
+	expected = "cannot map type 'struct2struct_test.flatAddress' to 'string', both must be structs"
+	if err == nil || err.Error() != expected {
+		t.Errorf("errors did not match, expected '%v', got '%v'", expected, err)
+	}
+
+	err = c.Register(struct2struct.MappingFor(flatAddress{}, structuredAddress{}).
+		Spread("Name", []string{"First", "Last"}, func(ctx context.Context, value interface{}) ([]interface{}, error) {
+			return []interface{}{value}, nil
+		}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var out structuredAddress
+	err = c.Marshal(flatAddress{Name: "Jane"}, &out)
+	expected = "Name: expected 2 values, got 1"
+	if err == nil || err.Error() != expected {
+		t.Errorf("errors did not match, expected '%v', got '%v'", expected, err)
+	}
+
+	err = c.Register(struct2struct.MappingFor(flatAddress{}, structuredAddress{}).
+		Compute("Address", []string{"Zip"}, func(ctx context.Context, values ...interface{}) (interface{}, error) {
+			return nil, errors.New("invalid zip")
+		}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = c.Marshal(flatAddress{}, &out)
+	expected = "Address: invalid zip"
+	if err == nil || err.Error() != expected {
+		t.Errorf("errors did not match, expected '%v', got '%v'", expected, err)
+	}
+}
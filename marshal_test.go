@@ -1,8 +1,11 @@
 package struct2struct_test
 
 import (
+	"context"
 	"errors"
+	"fmt"
 	"reflect"
+	"strings"
 	"testing"
 
 	"github.com/theothertomelliott/struct2struct"
@@ -32,6 +35,7 @@ type marshalTest struct {
 	expected   interface{}
 	comparator func(e interface{}, g interface{}) (bool, string)
 	err        error
+	opts       []struct2struct.Option
 }
 
 func TestMarshalStructs(t *testing.T) {
@@ -268,7 +272,7 @@ func TestMarshalStructs(t *testing.T) {
 			other: &struct {
 				SubStruct TwoIntsB
 			}{},
-			err: errors.New("SubStruct: First: strconv.Atoi: parsing \"first\": invalid syntax"),
+			err: errors.New("SubStruct: First: strconv.ParseInt: parsing \"first\": invalid syntax"),
 		},
 	}
 	executeTests(t, tests)
@@ -370,7 +374,7 @@ func TestMarshalSlices(t *testing.T) {
 				"a", "b",
 			},
 			other: &[]int{},
-			err:   errors.New("strconv.Atoi: parsing \"a\": invalid syntax"),
+			err:   errors.New("strconv.ParseInt: parsing \"a\": invalid syntax"),
 		},
 		{
 			name: "Non-slice to slice error",
@@ -450,7 +454,7 @@ func TestMarshalMaps(t *testing.T) {
 				"abc": "val-a",
 			},
 			other: &map[int]interface{}{},
-			err:   errors.New("strconv.Atoi: parsing \"abc\": invalid syntax"),
+			err:   errors.New("strconv.ParseInt: parsing \"abc\": invalid syntax"),
 		},
 		{
 			name: "Invalid value mapping",
@@ -458,7 +462,7 @@ func TestMarshalMaps(t *testing.T) {
 				"key-a": "abc",
 			},
 			other: &map[string]int{},
-			err:   errors.New("strconv.Atoi: parsing \"abc\": invalid syntax"),
+			err:   errors.New("strconv.ParseInt: parsing \"abc\": invalid syntax"),
 		},
 		{
 			name: "string->string to string->interface{}",
@@ -476,6 +480,752 @@ func TestMarshalMaps(t *testing.T) {
 	executeTests(t, tests)
 }
 
+func TestMarshalMapStructs(t *testing.T) {
+	var tests = []marshalTest{
+		{
+			name: "Map to struct",
+			in: map[string]interface{}{
+				"First":   10,
+				"SecondB": "20",
+				"Unused":  true,
+			},
+			other: &TwoIntsB{},
+			expected: &TwoIntsB{
+				First:   10,
+				SecondB: 20,
+			},
+		},
+		{
+			name: "Map to struct, tagged",
+			in: map[string]interface{}{
+				"First":   10,
+				"SecondB": 20,
+			},
+			other: &TwoIntsA{},
+			expected: &TwoIntsA{
+				First: 10,
+			},
+		},
+		{
+			name: "Map to struct, nested",
+			in: map[string]interface{}{
+				"SubStruct": map[string]interface{}{
+					"First":   10,
+					"SecondB": 20,
+				},
+				"SubPtr": map[string]interface{}{
+					"First": 30,
+				},
+			},
+			other: &struct {
+				SubStruct TwoIntsB
+				SubPtr    *TwoIntsB
+			}{},
+			expected: &struct {
+				SubStruct TwoIntsB
+				SubPtr    *TwoIntsB
+			}{
+				SubStruct: TwoIntsB{
+					First:   10,
+					SecondB: 20,
+				},
+				SubPtr: &TwoIntsB{
+					First: 30,
+				},
+			},
+		},
+		{
+			name: "Map to struct, nil value",
+			in: map[string]interface{}{
+				"First": nil,
+			},
+			other: &TwoIntsB{
+				First: 5,
+			},
+			expected: &TwoIntsB{
+				First: 5,
+			},
+		},
+		{
+			name: "Map to struct, invalid value",
+			in: map[string]interface{}{
+				"First": "abc",
+			},
+			other: &TwoIntsB{},
+			err:   errors.New("First: strconv.ParseInt: parsing \"abc\": invalid syntax"),
+		},
+		{
+			name: "Struct to map",
+			in: TwoIntsB{
+				First:   10,
+				SecondB: 20,
+			},
+			other: &map[string]interface{}{},
+			expected: &map[string]interface{}{
+				"First":   10,
+				"SecondB": 20,
+			},
+		},
+		{
+			name: "Struct to map, unexported fields",
+			in: struct {
+				Exported   string
+				unexported string
+			}{
+				Exported:   "abc",
+				unexported: "def",
+			},
+			other: &map[string]string{},
+			expected: &map[string]string{
+				"Exported": "abc",
+			},
+		},
+		{
+			name: "Struct to map, nested",
+			in: struct {
+				SubStruct TwoIntsB
+				SubPtr    *TwoIntsB
+			}{
+				SubStruct: TwoIntsB{
+					First:   10,
+					SecondB: 20,
+				},
+				SubPtr: &TwoIntsB{
+					First: 30,
+				},
+			},
+			other: &map[string]interface{}{},
+			expected: &map[string]interface{}{
+				"SubStruct": map[string]interface{}{
+					"First":   10,
+					"SecondB": 20,
+				},
+				"SubPtr": map[string]interface{}{
+					"First":   30,
+					"SecondB": 0,
+				},
+			},
+		},
+		{
+			name: "Struct to map, nested slices and maps",
+			in: struct {
+				Slice []TwoIntsB
+				Map   map[string]*TwoIntsB
+				Deep  [][]TwoIntsB
+				Plain []int
+			}{
+				Slice: []TwoIntsB{{First: 1}},
+				Map:   map[string]*TwoIntsB{"a": {First: 2}},
+				Deep:  [][]TwoIntsB{{{First: 3}}},
+				Plain: []int{4},
+			},
+			other: &map[string]interface{}{},
+			expected: &map[string]interface{}{
+				"Slice": []interface{}{
+					map[string]interface{}{"First": 1, "SecondB": 0},
+				},
+				"Map": map[string]interface{}{
+					"a": map[string]interface{}{"First": 2, "SecondB": 0},
+				},
+				"Deep": []interface{}{
+					[]interface{}{
+						map[string]interface{}{"First": 3, "SecondB": 0},
+					},
+				},
+				"Plain": []int{4},
+			},
+		},
+		{
+			name: "Struct to map, non-string keys",
+			in: TwoIntsB{
+				First: 10,
+			},
+			other: &map[int]interface{}{},
+			err:   errors.New("cannot apply a map type to a non-map"),
+		},
+	}
+	executeTests(t, tests)
+}
+
+func TestMarshalMergeMaps(t *testing.T) {
+	var tests = []marshalTest{
+		{
+			name: "Replace by default",
+			in: map[string]string{
+				"key-a": "new-a",
+			},
+			other: &map[string]string{
+				"key-a": "val-a",
+				"key-b": "val-b",
+			},
+			expected: &map[string]string{
+				"key-a": "new-a",
+			},
+		},
+		{
+			name: "Merge keys",
+			in: map[string]string{
+				"key-a": "new-a",
+				"key-c": "new-c",
+			},
+			other: &map[string]string{
+				"key-a": "val-a",
+				"key-b": "val-b",
+			},
+			expected: &map[string]string{
+				"key-a": "new-a",
+				"key-b": "val-b",
+				"key-c": "new-c",
+			},
+			opts: []struct2struct.Option{struct2struct.MergeMaps()},
+		},
+		{
+			name: "Merge nested maps",
+			in: map[string]interface{}{
+				"db": map[string]interface{}{
+					"host": "prod",
+				},
+			},
+			other: &map[string]interface{}{
+				"db": map[string]interface{}{
+					"host": "localhost",
+					"port": 5432,
+				},
+				"debug": true,
+			},
+			expected: &map[string]interface{}{
+				"db": map[string]interface{}{
+					"host": "prod",
+					"port": 5432,
+				},
+				"debug": true,
+			},
+			opts: []struct2struct.Option{struct2struct.MergeMaps()},
+		},
+		{
+			name: "Merge struct values",
+			in: map[string]map[string]interface{}{
+				"a": {
+					"First": 100,
+				},
+			},
+			other: &map[string]TwoIntsB{
+				"a": {
+					First:   1,
+					SecondB: 2,
+				},
+			},
+			expected: &map[string]TwoIntsB{
+				"a": {
+					First:   100,
+					SecondB: 2,
+				},
+			},
+			opts: []struct2struct.Option{struct2struct.MergeMaps()},
+		},
+		{
+			name: "Merge struct pointer values",
+			in: map[string]TwoIntsA{
+				"a": {
+					First: 100,
+				},
+			},
+			other: &map[string]*TwoIntsB{
+				"a": {
+					First:   1,
+					SecondB: 2,
+				},
+				"b": {
+					First: 3,
+				},
+			},
+			expected: &map[string]*TwoIntsB{
+				"a": {
+					First:   100,
+					SecondB: 0,
+				},
+				"b": {
+					First: 3,
+				},
+			},
+			opts: []struct2struct.Option{struct2struct.MergeMaps()},
+		},
+		{
+			name: "Merge same type struct values",
+			in: map[string]mergeConfig{
+				"a": {Tags: map[string]string{"k2": "v2"}},
+			},
+			other: &map[string]mergeConfig{
+				"a": {Tags: map[string]string{"k1": "v1"}},
+			},
+			expected: &map[string]mergeConfig{
+				"a": {Tags: map[string]string{"k1": "v1", "k2": "v2"}},
+			},
+			opts: []struct2struct.Option{struct2struct.MergeMaps()},
+		},
+		{
+			name: "Merge same type structs",
+			in: mergeConfig{
+				Name: "prod",
+				Tags: map[string]string{"k2": "v2"},
+				Next: &mergeConfig{Tags: map[string]string{"k4": "v4"}},
+			},
+			other: &mergeConfig{
+				Tags: map[string]string{"k1": "v1"},
+				Next: &mergeConfig{Tags: map[string]string{"k3": "v3"}},
+			},
+			expected: &mergeConfig{
+				Name: "prod",
+				Tags: map[string]string{"k1": "v1", "k2": "v2"},
+				Next: &mergeConfig{Tags: map[string]string{"k3": "v3", "k4": "v4"}},
+			},
+			opts: []struct2struct.Option{struct2struct.MergeMaps()},
+		},
+	}
+	executeTests(t, tests)
+}
+
+type mergeConfig struct {
+	Name string
+	Tags map[string]string
+	Next *mergeConfig
+}
+
+type deepCopyStruct struct {
+	Slice     []string
+	Map       map[string][]int
+	Ptr       *TwoIntsA
+	Interface interface{}
+	Array     [1][]string
+	private   string
+}
+
+func TestMarshalDeepCopy(t *testing.T) {
+	newSource := func() deepCopyStruct {
+		return deepCopyStruct{
+			Slice:     []string{"a"},
+			Map:       map[string][]int{"a": {1}},
+			Ptr:       &TwoIntsA{First: 1},
+			Interface: []string{"b"},
+			Array:     [1][]string{{"c"}},
+			private:   "private",
+		}
+	}
+
+	var shallow, deep deepCopyStruct
+	in := newSource()
+	if err := struct2struct.Marshal(in, &shallow); err != nil {
+		t.Fatal(err)
+	}
+	if err := struct2struct.Marshal(in, &deep, struct2struct.DeepCopy()); err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(newSource(), deep) {
+		t.Errorf("values did not match, expected '%v', got '%v'", newSource(), deep)
+	}
+
+	in.Slice[0] = "changed"
+	in.Map["a"][0] = 100
+	in.Ptr.First = 100
+	in.Interface.([]string)[0] = "changed"
+	in.Array[0][0] = "changed"
+
+	if reflect.DeepEqual(newSource(), shallow) {
+		t.Error("expected shallow copy to share memory with source")
+	}
+	if !reflect.DeepEqual(newSource(), deep) {
+		t.Errorf("deep copy was modified, expected '%v', got '%v'", newSource(), deep)
+	}
+}
+
+type deepCopyPrivate struct {
+	Name string
+	list []string
+	ptr  *TwoIntsA
+}
+
+func TestMarshalDeepCopyUnexported(t *testing.T) {
+	in := deepCopyPrivate{
+		Name: "name",
+		list: []string{"a"},
+		ptr:  &TwoIntsA{First: 1},
+	}
+
+	var shared, deep deepCopyPrivate
+	if err := struct2struct.Marshal(in, &shared, struct2struct.DeepCopy()); err != nil {
+		t.Fatal(err)
+	}
+	err := struct2struct.Marshal(in, &deep, struct2struct.DeepCopy(), struct2struct.UnexportedFields())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	in.list[0] = "changed"
+	in.ptr.First = 100
+
+	if shared.list[0] != "changed" || shared.ptr.First != 100 {
+		t.Error("expected unexported fields to be shared without UnexportedFields")
+	}
+	expected := deepCopyPrivate{
+		Name: "name",
+		list: []string{"a"},
+		ptr:  &TwoIntsA{First: 1},
+	}
+	if !reflect.DeepEqual(expected, deep) {
+		t.Errorf("deep copy was modified, expected '%v', got '%v'", expected, deep)
+	}
+}
+
+type nodeA struct {
+	Name     string
+	Parent   *nodeA
+	Children []*nodeA
+}
+
+type nodeB struct {
+	Name     string
+	Parent   *nodeB
+	Children []*nodeB
+}
+
+type listNode struct {
+	Name string
+	Next *listNode
+}
+
+func TestMarshalCycles(t *testing.T) {
+	root := &nodeA{Name: "root"}
+	child := &nodeA{Name: "child", Parent: root}
+	root.Children = []*nodeA{child, child}
+
+	var out *nodeB
+	if err := struct2struct.Marshal(root, &out); err != nil {
+		t.Fatal(err)
+	}
+	if out.Name != "root" || len(out.Children) != 2 {
+		t.Fatalf("unexpected result: %+v", out)
+	}
+	if out.Children[0] != out.Children[1] {
+		t.Error("expected shared source pointers to map to a single target pointer")
+	}
+	if out.Children[0].Parent != out {
+		t.Error("expected parent pointer to refer back to the root")
+	}
+
+	var deep *nodeA
+	if err := struct2struct.Marshal(root, &deep, struct2struct.DeepCopy()); err != nil {
+		t.Fatal(err)
+	}
+	if deep == root || deep.Children[0] == child {
+		t.Error("expected deep copy not to share pointers with the source")
+	}
+	if deep.Children[0].Parent != deep {
+		t.Error("expected deep copied parent pointer to refer back to the root")
+	}
+
+	loop := &listNode{Name: "loop"}
+	loop.Next = loop
+	var asMap map[string]interface{}
+	err := struct2struct.Marshal(loop, &asMap)
+	expectedErr := "Next: cycle detected applying type '*struct2struct_test.listNode' to 'map[string]interface {}'"
+	if err == nil || err.Error() != expectedErr {
+		t.Errorf("errors did not match, expected '%v', got '%v'", expectedErr, err)
+	}
+}
+
+func TestMarshalMaxDepth(t *testing.T) {
+	nested := map[string]interface{}{
+		"a": map[string]interface{}{
+			"b": map[string]interface{}{
+				"c": "value",
+			},
+		},
+	}
+	var tests = []marshalTest{
+		{
+			name:     "Within limit",
+			in:       nested,
+			other:    &map[string]interface{}{},
+			expected: &nested,
+			opts:     []struct2struct.Option{struct2struct.MaxDepth(10)},
+		},
+		{
+			name: "Exceeds limit",
+			in: struct {
+				Sub struct{ First int }
+			}{},
+			other: &struct {
+				Sub struct{ First int64 }
+			}{},
+			err:  errors.New("Sub: First: maximum depth of 2 exceeded"),
+			opts: []struct2struct.Option{struct2struct.MaxDepth(2)},
+		},
+	}
+	executeTests(t, tests)
+
+	var out struct {
+		A struct {
+			B struct {
+				C string
+			}
+		}
+	}
+	err := struct2struct.Marshal(map[string]interface{}{
+		"A": map[string]interface{}{
+			"B": map[string]interface{}{
+				"C": "value",
+			},
+		},
+	}, &out, struct2struct.MaxDepth(4))
+	var depthErr *struct2struct.DepthError
+	if !errors.As(err, &depthErr) {
+		t.Fatalf("expected a DepthError, got '%v'", err)
+	}
+	if depthErr.Limit != 4 {
+		t.Errorf("expected limit of 4, got %d", depthErr.Limit)
+	}
+}
+
+func TestMarshalContext(t *testing.T) {
+	var out []int
+	err := struct2struct.MarshalContext(context.Background(), []string{"1", "2"}, &out)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual([]int{1, 2}, out) {
+		t.Errorf("values did not match, expected '%v', got '%v'", []int{1, 2}, out)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	err = struct2struct.MarshalContext(ctx, []string{"1", "2"}, &out)
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("expected context.Canceled, got '%v'", err)
+	}
+}
+
+type cancelItem struct {
+	ID int
+}
+
+type cancelTarget struct {
+	ID int64
+}
+
+type cancelKey struct{}
+
+// cancelRecorder records the IDs of applied items, cancelling after a number of them.
+type cancelRecorder struct {
+	after   int
+	cancel  context.CancelFunc
+	applied []int
+}
+
+// countdownContext reports that it was cancelled after Err has been called a number of times.
+type countdownContext struct {
+	context.Context
+	remaining int
+}
+
+func (c *countdownContext) Err() error {
+	if c.remaining <= 0 {
+		return context.Canceled
+	}
+	c.remaining--
+	return nil
+}
+
+func TestMarshalContextCancelledPartway(t *testing.T) {
+	c := struct2struct.New()
+	err := c.RegisterFieldTransform(cancelItem{}, "ID", func(ctx context.Context, src interface{}, value interface{}) (interface{}, error) {
+		recorder := ctx.Value(cancelKey{}).(*cancelRecorder)
+		recorder.applied = append(recorder.applied, value.(int))
+		if len(recorder.applied) == recorder.after {
+			recorder.cancel()
+		}
+		return value, nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var tests = []struct {
+		name     string
+		in       interface{}
+		out      interface{}
+		after    int
+		expected int
+	}{
+		{
+			name:     "Slice",
+			in:       []cancelItem{{ID: 1}, {ID: 2}, {ID: 3}, {ID: 4}},
+			out:      &[]cancelTarget{},
+			after:    2,
+			expected: 2,
+		},
+		{
+			name:     "Map",
+			in:       map[string]cancelItem{"a": {ID: 1}, "b": {ID: 2}, "c": {ID: 3}},
+			out:      &map[string]cancelTarget{},
+			after:    1,
+			expected: 1,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+			recorder := &cancelRecorder{after: test.after, cancel: cancel}
+			ctx = context.WithValue(ctx, cancelKey{}, recorder)
+
+			err := c.MarshalContext(ctx, test.in, test.out)
+			if !errors.Is(err, context.Canceled) {
+				t.Errorf("expected context.Canceled, got '%v'", err)
+			}
+			if len(recorder.applied) != test.expected {
+				t.Errorf("expected %d items to be applied, got '%v'", test.expected, recorder.applied)
+			}
+		})
+	}
+
+	t.Run("Deep copy", func(t *testing.T) {
+		// Allow the check in MarshalContext and two elements.
+		ctx := &countdownContext{Context: context.Background(), remaining: 3}
+		var out []int
+		err := struct2struct.MarshalContext(ctx, []int{1, 2, 3, 4}, &out, struct2struct.DeepCopy())
+		if !errors.Is(err, context.Canceled) {
+			t.Errorf("expected context.Canceled, got '%v'", err)
+		}
+		if out != nil {
+			t.Errorf("expected no values to be applied, got '%v'", out)
+		}
+		if ctx.remaining != 0 {
+			t.Errorf("expected every check to be used, %d remaining", ctx.remaining)
+		}
+	})
+}
+
+type hookSource struct {
+	First string
+	Last  string
+	Email string
+}
+
+func (h *hookSource) BeforeStructConvert(ctx context.Context, other interface{}) error {
+	h.Email = strings.ToLower(h.Email)
+	return nil
+}
+
+type hookTarget struct {
+	First    string
+	Last     string
+	Email    string
+	FullName string
+	Tenant   string
+}
+
+type tenantKey struct{}
+
+func (h *hookTarget) AfterStructConvert(ctx context.Context, other interface{}) error {
+	if _, ok := other.(*hookSource); !ok {
+		return fmt.Errorf("unexpected counterpart %T", other)
+	}
+	h.FullName = h.First + " " + h.Last
+	h.Tenant, _ = ctx.Value(tenantKey{}).(string)
+	return nil
+}
+
+type failingHook struct {
+	First string
+}
+
+func (failingHook) BeforeStructConvert(ctx context.Context, other interface{}) error {
+	return errors.New("hook failed")
+}
+
+func TestMarshalHooks(t *testing.T) {
+	in := struct {
+		Person hookSource
+	}{
+		Person: hookSource{
+			First: "Jane",
+			Last:  "Doe",
+			Email: "Jane@Example.COM",
+		},
+	}
+	var out struct {
+		Person *hookTarget
+	}
+	ctx := context.WithValue(context.Background(), tenantKey{}, "tenant")
+	if err := struct2struct.MarshalContext(ctx, in, &out); err != nil {
+		t.Fatal(err)
+	}
+	expected := &hookTarget{
+		First:    "Jane",
+		Last:     "Doe",
+		Email:    "jane@example.com",
+		FullName: "Jane Doe",
+		Tenant:   "tenant",
+	}
+	if !reflect.DeepEqual(expected, out.Person) {
+		t.Errorf("values did not match, expected '%v', got '%v'", expected, out.Person)
+	}
+	if in.Person.Email != "Jane@Example.COM" {
+		t.Errorf("source was modified: %v", in.Person.Email)
+	}
+
+	var tests = []marshalTest{
+		{
+			name: "Hook error",
+			in: struct {
+				Sub failingHook
+			}{},
+			other: &struct {
+				Sub TwoIntsA
+			}{},
+			err: errors.New("Sub: hook failed"),
+		},
+	}
+	executeTests(t, tests)
+}
+
+type mapHookTarget struct {
+	First    string
+	Last     string
+	FullName string
+}
+
+func (h *mapHookTarget) AfterStructConvert(ctx context.Context, other interface{}) error {
+	if _, ok := other.(*map[string]interface{}); !ok {
+		return fmt.Errorf("unexpected counterpart %T", other)
+	}
+	h.FullName = h.First + " " + h.Last
+	return nil
+}
+
+func TestMarshalHooksWithMaps(t *testing.T) {
+	var out mapHookTarget
+	err := struct2struct.Marshal(map[string]interface{}{"First": "Jane", "Last": "Doe"}, &out)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if out.FullName != "Jane Doe" {
+		t.Errorf("expected hook to set FullName, got '%v'", out.FullName)
+	}
+
+	in := hookSource{Email: "Jane@Example.COM"}
+	var asMap map[string]interface{}
+	if err := struct2struct.Marshal(in, &asMap); err != nil {
+		t.Fatal(err)
+	}
+	if asMap["Email"] != "jane@example.com" {
+		t.Errorf("expected hook to normalize Email, got '%v'", asMap["Email"])
+	}
+	if in.Email != "Jane@Example.COM" {
+		t.Errorf("source was modified: %v", in.Email)
+	}
+}
+
 func TestMarshalToInt(t *testing.T) {
 	var tests = []marshalTest{
 		{
@@ -650,7 +1400,7 @@ func TestMarshalToUint(t *testing.T) {
 			name:  "Invalid string to uint",
 			in:    []string{"abc"},
 			other: &[]uint{},
-			err:   errors.New("strconv.Atoi: parsing \"abc\": invalid syntax"),
+			err:   errors.New("strconv.ParseUint: parsing \"abc\": invalid syntax"),
 		},
 	}
 	executeTests(t, tests)
@@ -786,6 +1536,7 @@ func executeTests(t *testing.T, tests []marshalTest) {
 			err := struct2struct.Marshal(
 				test.in,
 				test.other,
+				test.opts...,
 			)
 			if test.err == nil && err != nil {
 				t.Error(err)
@@ -815,3 +1566,38 @@ func executeTests(t *testing.T, tests []marshalTest) {
 func stringPtr(in string) *string {
 	return &in
 }
+
+func TestMarshalCyclesToValue(t *testing.T) {
+	a := &nodeA{Name: "a"}
+	b := &nodeA{Name: "b", Parent: a}
+	a.Children = []*nodeA{b}
+
+	var out nodeB
+	if err := struct2struct.Marshal(a, &out); err != nil {
+		t.Fatal(err)
+	}
+	if out.Children[0].Parent != &out {
+		t.Error("expected parent pointer to refer back to the target")
+	}
+
+	list := []interface{}{"a", nil}
+	list[1] = list
+	var listCopy []interface{}
+	if err := struct2struct.Marshal(list, &listCopy, struct2struct.DeepCopy()); err != nil {
+		t.Fatal(err)
+	}
+	if inner := listCopy[1].([]interface{}); &inner[0] != &listCopy[0] || &list[0] == &listCopy[0] {
+		t.Error("expected the copied slice to contain itself")
+	}
+
+	m := map[string]interface{}{"name": "m"}
+	m["self"] = m
+	var mapCopy map[string]interface{}
+	if err := struct2struct.Marshal(m, &mapCopy, struct2struct.DeepCopy()); err != nil {
+		t.Fatal(err)
+	}
+	mapCopy["name"] = "copy"
+	if m["name"] != "m" || mapCopy["self"].(map[string]interface{})["name"] != "copy" {
+		t.Error("expected the copied map to contain itself")
+	}
+}
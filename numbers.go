@@ -0,0 +1,81 @@
+package struct2struct
+
+import (
+	"errors"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// parseInt parses str as a signed integer that fits in bitSize bits.
+// Integral values in exponent form, such as "1e3", are also accepted.
+func (s *state) parseInt(str string, bitSize int) (int64, error) {
+	str = s.numberText(str)
+	value, err := strconv.ParseInt(str, s.numberBase(), bitSize)
+	if errors.Is(err, strconv.ErrSyntax) {
+		if f, ok := parseIntegral(str); ok {
+			limit := math.Ldexp(1, bitSize-1)
+			if f < -limit || f >= limit {
+				return 0, &strconv.NumError{Func: "ParseInt", Num: str, Err: strconv.ErrRange}
+			}
+			return int64(f), nil
+		}
+	}
+	return value, err
+}
+
+// parseUint parses str as an unsigned integer that fits in bitSize bits.
+// Negative values are rejected rather than wrapped around.
+func (s *state) parseUint(str string, bitSize int) (uint64, error) {
+	str = s.numberText(str)
+	value, err := strconv.ParseUint(str, s.numberBase(), bitSize)
+	if errors.Is(err, strconv.ErrSyntax) {
+		if f, ok := parseIntegral(str); ok {
+			if f < 0 || f >= math.Ldexp(1, bitSize) {
+				return 0, &strconv.NumError{Func: "ParseUint", Num: str, Err: strconv.ErrRange}
+			}
+			return uint64(f), nil
+		}
+	}
+	return value, err
+}
+
+// parseFloat parses str as a floating point number of the given bit size.
+func (s *state) parseFloat(str string, bitSize int) (float64, error) {
+	return strconv.ParseFloat(s.numberText(str), bitSize)
+}
+
+// numberText trims str and normalizes any separators configured with NumberSeparators.
+func (s *state) numberText(str string) string {
+	str = strings.TrimSpace(str)
+	if s.groupSeparator == 0 && s.decimalSeparator == 0 {
+		return str
+	}
+	return strings.Map(func(r rune) rune {
+		switch r {
+		case s.groupSeparator:
+			return -1
+		case s.decimalSeparator:
+			return '.'
+		}
+		return r
+	}, str)
+}
+
+// numberBase returns the base for parsing integers, with zero
+// allowing base prefixes and underscores as in Go integer literals.
+func (s *state) numberBase() int {
+	if s.numberPrefixes {
+		return 0
+	}
+	return 10
+}
+
+// parseIntegral parses str as a decimal number with no fractional part.
+func parseIntegral(str string) (float64, bool) {
+	f, err := strconv.ParseFloat(str, 64)
+	if err != nil || f != math.Trunc(f) {
+		return 0, false
+	}
+	return f, true
+}
@@ -0,0 +1,115 @@
+package struct2struct_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/theothertomelliott/struct2struct"
+)
+
+func TestMarshalParseNumbers(t *testing.T) {
+	var tests = []marshalTest{
+		{
+			name:     "Whitespace",
+			in:       []string{" 12\n", "\t-3 "},
+			other:    &[]int{},
+			expected: &[]int{12, -3},
+		},
+		{
+			name:     "Int64 range",
+			in:       []string{"9223372036854775807"},
+			other:    &[]int64{},
+			expected: &[]int64{9223372036854775807},
+		},
+		{
+			name:  "Int8 out of range",
+			in:    []string{"128"},
+			other: &[]int8{},
+			err:   errors.New("strconv.ParseInt: parsing \"128\": value out of range"),
+		},
+		{
+			name:     "Exponent",
+			in:       []string{"1e3", "-2.5E2"},
+			other:    &[]int{},
+			expected: &[]int{1000, -250},
+		},
+		{
+			name:  "Exponent out of range",
+			in:    []string{"1e3"},
+			other: &[]int8{},
+			err:   errors.New("strconv.ParseInt: parsing \"1e3\": value out of range"),
+		},
+		{
+			name:  "Fraction",
+			in:    []string{"1.5"},
+			other: &[]int{},
+			err:   errors.New("strconv.ParseInt: parsing \"1.5\": invalid syntax"),
+		},
+		{
+			name:  "Negative uint",
+			in:    []string{"-5"},
+			other: &[]uint{},
+			err:   errors.New("strconv.ParseUint: parsing \"-5\": value out of range"),
+		},
+		{
+			name:  "Uint8 out of range",
+			in:    []string{"256"},
+			other: &[]uint8{},
+			err:   errors.New("strconv.ParseUint: parsing \"256\": value out of range"),
+		},
+		{
+			name:     "Uint exponent",
+			in:       []string{"2e2"},
+			other:    &[]uint8{},
+			expected: &[]uint8{200},
+		},
+		{
+			name:  "Prefix without option",
+			in:    []string{"0x1F"},
+			other: &[]int{},
+			err:   errors.New("strconv.ParseInt: parsing \"0x1F\": invalid syntax"),
+		},
+		{
+			name:     "Prefixes",
+			in:       []string{"0x1F", "0o17", "0b101", "1_000", "-0x10"},
+			other:    &[]int{},
+			expected: &[]int{31, 15, 5, 1000, -16},
+			opts:     []struct2struct.Option{struct2struct.NumberPrefixes()},
+		},
+		{
+			name:     "Uint prefixes",
+			in:       []string{"0xff"},
+			other:    &[]uint8{},
+			expected: &[]uint8{255},
+			opts:     []struct2struct.Option{struct2struct.NumberPrefixes()},
+		},
+		{
+			name:  "Separators without option",
+			in:    []string{"1,234.5"},
+			other: &[]float64{},
+			err:   errors.New("strconv.ParseFloat: parsing \"1,234.5\": invalid syntax"),
+		},
+		{
+			name:     "Separators",
+			in:       []string{"1,234.5", " 1,000,000 "},
+			other:    &[]float64{},
+			expected: &[]float64{1234.5, 1000000},
+			opts:     []struct2struct.Option{struct2struct.NumberSeparators(',', '.')},
+		},
+		{
+			name:     "European separators",
+			in:       []string{"1.234,5"},
+			other:    &[]float64{},
+			expected: &[]float64{1234.5},
+			opts:     []struct2struct.Option{struct2struct.NumberSeparators('.', ',')},
+		},
+		{
+			name:     "Integer separators",
+			in:       []string{"1,234"},
+			other:    &[]int{},
+			expected: &[]int{1234},
+			opts:     []struct2struct.Option{struct2struct.NumberSeparators(',', '.')},
+		},
+	}
+	executeTests(t, tests)
+}
@@ -0,0 +1,106 @@
+package struct2struct
+
+// Option configures a Converter.
+type Option func(*Converter)
+
+// MergeMaps applies source map entries into an existing target map instead of replacing it.
+// Values for keys present in both maps are merged recursively, so nested structs and maps
+// keep any fields or keys not set by the source.
+func MergeMaps() Option {
+	return func(c *Converter) {
+		c.mergeMaps = true
+	}
+}
+
+// DeepCopy recursively clones slices, maps and pointers that would otherwise be
+// shared between source and target because their types match.
+// Mutating the target after a deep copy never affects the source, except through unexported
+// fields: these are copied as they are, sharing memory, unless UnexportedFields is also set.
+func DeepCopy() Option {
+	return func(c *Converter) {
+		c.deepCopy = true
+	}
+}
+
+// MaxDepth limits how deeply nested values may be applied, counting each struct field,
+// collection element and pointer or interface indirection as one level.
+// Exceeding the limit returns a *DepthError. A limit of zero or less means no limit.
+func MaxDepth(depth int) Option {
+	return func(c *Converter) {
+		c.maxDepth = depth
+	}
+}
+
+// FuncFields sets how funcs and channels are applied to types they cannot be assigned to,
+// such as a func() int field matched with a func() int64 field.
+func FuncFields(policy FuncPolicy) Option {
+	return func(c *Converter) {
+		c.funcPolicy = policy
+	}
+}
+
+// StrictEnums returns an *EnumError when converting a value missing from a registered enum,
+// instead of converting it as a plain number or string.
+func StrictEnums() Option {
+	return func(c *Converter) {
+		c.strictEnums = true
+	}
+}
+
+// NumberPrefixes allows strings parsed as integers to use base prefixes and underscores
+// as in Go integer literals, such as "0x1F", "0o17", "0b101" and "1_000".
+func NumberPrefixes() Option {
+	return func(c *Converter) {
+		c.numberPrefixes = true
+	}
+}
+
+// NumberSeparators sets the digit group and decimal separators of strings parsed as numbers,
+// so that "1,234.5" can be parsed with NumberSeparators(',', '.') and "1.234,5" with
+// NumberSeparators('.', ',').
+func NumberSeparators(group rune, decimal rune) Option {
+	return func(c *Converter) {
+		c.groupSeparator = group
+		c.decimalSeparator = decimal
+	}
+}
+
+// FloatFormat sets how floats are formatted when applied to strings, using the format
+// and precision accepted by strconv.FormatFloat. For example, FloatFormat('f', 2)
+// formats 1e6 as "1000000.00" and FloatFormat('f', -1) formats it as "1000000".
+func FloatFormat(format byte, precision int) Option {
+	return func(c *Converter) {
+		c.floatFormat = format
+		c.floatPrecision = precision
+	}
+}
+
+// IntegerBase sets the base integers are formatted in when applied to strings,
+// from 2 to 36. Other bases are ignored, leaving integers formatted in base 10.
+// Strings are still parsed as base 10 unless NumberPrefixes is set.
+func IntegerBase(base int) Option {
+	return func(c *Converter) {
+		if base < 2 || base > 36 {
+			base = 0
+		}
+		c.integerBase = base
+	}
+}
+
+// UnexportedFields applies unexported struct fields as if they were exported.
+// Unexported fields are read and written using package unsafe, so this is intended
+// for converting types within the same package or in tests.
+func UnexportedFields() Option {
+	return func(c *Converter) {
+		c.unexportedFields = true
+	}
+}
+
+// StrictUnexported returns an *UnexportedFieldError when an unexported field matches a field
+// of the other struct, instead of leaving the target unchanged. It has no effect if
+// UnexportedFields is set.
+func StrictUnexported() Option {
+	return func(c *Converter) {
+		c.strictUnexported = true
+	}
+}
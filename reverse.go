@@ -0,0 +1,196 @@
+package struct2struct
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"sort"
+)
+
+// Reverse returns a Mapping from the target type of m to its source type.
+// Renamed and ignored fields are reversed. Computed and spread fields and
+// functions added with Convert cannot be reversed, and are reported by OneWay.
+func (m *Mapping) Reverse() *Mapping {
+	r := &Mapping{src: m.dst, dst: m.src}
+	for src, dst := range m.fields {
+		r.Field(dst, src)
+	}
+	for src := range m.ignored {
+		if _, ok := m.dst.FieldByName(src); ok {
+			r.Ignore(src)
+		}
+	}
+	return r
+}
+
+// RegisterBidirectional adds m and its reverse to the mappings used by c,
+// returning the fields that would not survive conversion in both directions.
+func (c *Converter) RegisterBidirectional(m *Mapping) ([]FieldError, error) {
+	if err := c.Register(m); err != nil {
+		return nil, err
+	}
+	if err := c.Register(m.Reverse()); err != nil {
+		return nil, err
+	}
+	return c.OneWay(m.src, m.dst), nil
+}
+
+// OneWay returns the fields of the struct type src that would not survive being converted
+// to the struct type dst and back again, either because they are not applied in both
+// directions or because converting their values may lose information.
+func (c *Converter) OneWay(src reflect.Type, dst reflect.Type) []FieldError {
+	var fields []FieldError
+	c.oneWay("", indirectType(src), indirectType(dst), make(map[typePair]bool), &fields)
+	sort.SliceStable(fields, func(i, j int) bool {
+		return fields[i].Path < fields[j].Path
+	})
+	return fields
+}
+
+func (c *Converter) oneWay(path string, src reflect.Type, dst reflect.Type, visiting map[typePair]bool, fields *[]FieldError) {
+	pair := typePair{src: src, dst: dst}
+	if visiting[pair] {
+		return
+	}
+	visiting[pair] = true
+	defer delete(visiting, pair)
+
+	forward := c.mappings[pair]
+	backward := c.mappings[typePair{src: dst, dst: src}]
+
+	srcFields := matchFields(src, dst)
+	dstFields := matchFields(dst, src)
+	forward.remap(srcFields, dstFields)
+
+	// Find the source field each target field is set from, and back again.
+	returned := make(map[string]string)
+	backSrc := matchFields(dst, src)
+	backDst := matchFields(src, dst)
+	backward.remap(backSrc, backDst)
+	for name, dstField := range backSrc {
+		if srcField, ok := backDst[name]; ok {
+			returned[dstField.Name] = srcField.Name
+		}
+	}
+	computed := make(map[string]bool)
+	if forward != nil {
+		for _, field := range forward.computed {
+			for _, name := range field.srcs {
+				computed[name] = true
+			}
+		}
+		for _, field := range forward.spread {
+			computed[field.src] = true
+		}
+	}
+
+	fail := func(field reflect.StructField, err error) {
+		*fields = append(*fields, FieldError{Path: joinPath(path, field.Name), Src: src, Dst: dst, Err: err})
+	}
+	forwardMatched := make(map[string]bool)
+	for name, srcField := range srcFields {
+		dstField, ok := dstFields[name]
+		if !ok || srcField.PkgPath != "" || dstField.PkgPath != "" {
+			continue
+		}
+		forwardMatched[srcField.Name] = true
+		if returned[dstField.Name] != srcField.Name {
+			fail(srcField.StructField, fmt.Errorf("'%v' is not applied back to this field", dstField.Name))
+			continue
+		}
+		opts, _ := parseTags(srcField.Tag, dstField.Tag)
+		c.lossy(joinPath(path, srcField.Name), srcField.Type, dstField.Type, opts, visiting, fields)
+	}
+	for i := 0; i < src.NumField(); i++ {
+		field := src.Field(i)
+		if field.PkgPath != "" || forwardMatched[field.Name] {
+			continue
+		}
+		if computed[field.Name] {
+			fail(field, errors.New("computed fields cannot be reversed"))
+			continue
+		}
+		fail(field, fmt.Errorf("not applied to '%v'", dst))
+	}
+}
+
+// lossy reports if applying a value of type src to dst with the tag options opts and back again may lose information.
+func (c *Converter) lossy(path string, src reflect.Type, dst reflect.Type, opts tagOptions, visiting map[typePair]bool, fields *[]FieldError) {
+	fail := func(format string, args ...interface{}) {
+		*fields = append(*fields, FieldError{Path: path, Src: src, Dst: dst, Err: fmt.Errorf(format, args...)})
+	}
+
+	src = indirectType(src)
+	dst = indirectType(dst)
+	switch {
+	case src == dst:
+	case src.Kind() == reflect.Struct && dst.Kind() == reflect.Struct:
+		c.oneWay(path, src, dst, visiting, fields)
+	case (src.Kind() == reflect.Slice && dst.Kind() == reflect.Slice) ||
+		(src.Kind() == reflect.Map && dst.Kind() == reflect.Map):
+		c.lossy(path, src.Elem(), dst.Elem(), opts, visiting, fields)
+	case isNumber(src) && isNumber(dst):
+		if mayLosePrecision(src, dst) {
+			fail("converting '%v' to '%v' may lose precision", src, dst)
+		}
+	case isNumber(src) && dst.Kind() == reflect.String:
+		if err := c.formatLoss(src, opts.format); err != nil {
+			fail("%v", err)
+		}
+	default:
+		fail("converting '%v' to '%v' may not be reversible", src, dst)
+	}
+}
+
+// formatLoss returns an error if formatting numbers of type src as strings with format,
+// or the number formats of c, may not be parsed back to the same value.
+func (c *Converter) formatLoss(src reflect.Type, format string) error {
+	if format != "" {
+		return fmt.Errorf("formatting '%v' with format '%v' may not be reversible", src, format)
+	}
+	switch numberApplier(src) {
+	case "int", "uint":
+		if c.integerBase != 0 && c.integerBase != 10 {
+			return fmt.Errorf("formatting '%v' in base %v may not be reversible", src, c.integerBase)
+		}
+	case "float":
+		if c.floatFormat != 0 && c.floatPrecision >= 0 {
+			return fmt.Errorf("formatting '%v' with precision %v may lose precision", src, c.floatPrecision)
+		}
+	}
+	return nil
+}
+
+// mayLosePrecision reports whether some values of the numeric type src cannot be held by dst.
+func mayLosePrecision(src reflect.Type, dst reflect.Type) bool {
+	switch {
+	case isFloat(src) && !isFloat(dst):
+		return true
+	case isFloat(dst) && isFloat(src):
+		return dst.Bits() < src.Bits()
+	case isFloat(dst):
+		// Integers are exact in floats up to the size of their mantissas.
+		mantissa := 53
+		if dst.Kind() == reflect.Float32 {
+			mantissa = 24
+		}
+		return src.Bits() > mantissa
+	case isUnsigned(src) == isUnsigned(dst):
+		return dst.Bits() < src.Bits()
+	case isUnsigned(dst):
+		return true
+	}
+	return dst.Bits() <= src.Bits()
+}
+
+func isFloat(t reflect.Type) bool {
+	return t.Kind() == reflect.Float32 || t.Kind() == reflect.Float64
+}
+
+func isUnsigned(t reflect.Type) bool {
+	switch t.Kind() {
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return true
+	}
+	return false
+}
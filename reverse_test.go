@@ -0,0 +1,156 @@
+package struct2struct_test
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/theothertomelliott/struct2struct"
+)
+
+type reverseDTO struct {
+	ID     int64
+	Name   string
+	Email  string
+	Secret string
+	Street string
+	City   string
+	Score  float64
+}
+
+type reverseDomain struct {
+	ID       int32
+	FullName string
+	Email    string
+	Secret   string
+	Address  string
+	Score    float64
+}
+
+func TestMappingReverse(t *testing.T) {
+	c := struct2struct.New()
+	oneWay, err := struct2struct.NewMapping[reverseDTO, reverseDomain]().
+		Field("Name", "FullName").
+		Ignore("Secret").
+		Compute("Address", []string{"Street", "City"}, func(ctx context.Context, values ...interface{}) (interface{}, error) {
+			return values[0].(string) + ", " + values[1].(string), nil
+		}).
+		RegisterBidirectional(c)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var paths []string
+	for _, field := range oneWay {
+		paths = append(paths, field.Error())
+	}
+	expected := []string{
+		"City: computed fields cannot be reversed",
+		"ID: converting 'int64' to 'int32' may lose precision",
+		"Secret: not applied to 'struct2struct_test.reverseDomain'",
+		"Street: computed fields cannot be reversed",
+	}
+	if !reflect.DeepEqual(expected, paths) {
+		t.Errorf("one-way fields did not match, expected '%v', got '%v'", expected, paths)
+	}
+
+	var dto reverseDTO
+	err = c.Marshal(reverseDomain{
+		ID:       1,
+		FullName: "Jane Doe",
+		Email:    "jane@example.com",
+		Secret:   "secret",
+		Score:    1.5,
+	}, &dto)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expectedDTO := reverseDTO{
+		ID:    1,
+		Name:  "Jane Doe",
+		Email: "jane@example.com",
+		Score: 1.5,
+	}
+	if !reflect.DeepEqual(expectedDTO, dto) {
+		t.Errorf("values did not match, expected '%v', got '%v'", expectedDTO, dto)
+	}
+}
+
+func TestOneWayTags(t *testing.T) {
+	oneWay := struct2struct.New().OneWay(reflect.TypeOf(TwoIntsA{}), reflect.TypeOf(TwoIntsB{}))
+	if len(oneWay) != 0 {
+		t.Errorf("expected tagged fields to be reversible, got '%v'", oneWay)
+	}
+
+	oneWay = struct2struct.New().OneWay(reflect.TypeOf(struct {
+		First  uint8
+		Second int
+	}{}), reflect.TypeOf(struct {
+		First int16
+	}{}))
+	if len(oneWay) != 1 || oneWay[0].Path != "Second" {
+		t.Errorf("expected only Second to be one-way, got '%v'", oneWay)
+	}
+}
+
+func TestOneWayNumbers(t *testing.T) {
+	type numbers struct {
+		Int64  int64
+		Int32  int32
+		Int16  int16
+		Uint   uint
+		Float  float64
+		Format int
+	}
+	type floats struct {
+		Int64  float64
+		Int32  float32
+		Int16  float32
+		Uint   float64
+		Float  string
+		Format string `format:"%x"`
+	}
+	var tests = []struct {
+		name     string
+		opts     []struct2struct.Option
+		expected []string
+	}{
+		{
+			name: "Default",
+			expected: []string{
+				"Format: formatting 'int' with format '%x' may not be reversible",
+				"Int32: converting 'int32' to 'float32' may lose precision",
+				"Int64: converting 'int64' to 'float64' may lose precision",
+				"Uint: converting 'uint' to 'float64' may lose precision",
+			},
+		},
+		{
+			name: "Number formats",
+			opts: []struct2struct.Option{struct2struct.FloatFormat('f', 2), struct2struct.IntegerBase(16)},
+			expected: []string{
+				"Float: formatting 'float64' with precision 2 may lose precision",
+				"Format: formatting 'int' with format '%x' may not be reversible",
+				"Int32: converting 'int32' to 'float32' may lose precision",
+				"Int64: converting 'int64' to 'float64' may lose precision",
+				"Uint: converting 'uint' to 'float64' may lose precision",
+			},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			oneWay := struct2struct.New(test.opts...).OneWay(reflect.TypeOf(numbers{}), reflect.TypeOf(floats{}))
+			var errs []string
+			for _, field := range oneWay {
+				errs = append(errs, field.Error())
+			}
+			if !reflect.DeepEqual(test.expected, errs) {
+				t.Errorf("one-way fields did not match, expected '%v', got '%v'", test.expected, errs)
+			}
+		})
+	}
+
+	oneWay := struct2struct.New(struct2struct.IntegerBase(16)).OneWay(reflect.TypeOf(struct{ N int }{}), reflect.TypeOf(struct{ N string }{}))
+	if len(oneWay) != 1 || oneWay[0].Error() != "N: formatting 'int' in base 16 may not be reversible" {
+		t.Errorf("expected N to be one-way, got '%v'", oneWay)
+	}
+}
@@ -0,0 +1,270 @@
+package struct2struct
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+const (
+	entriesValues = "values"
+	entriesKeys   = "keys"
+	entriesPairs  = "pairs"
+)
+
+// mapSliceApplier applies maps to slices, in order of their sorted keys.
+// By default the slice holds the values of the map. The field tags may instead select
+// the keys with `s2s:"keys"`, or key/value pairs with `s2s:"pairs"`, applied to the
+// Key and Value fields of struct elements.
+func mapSliceApplier(s *state, iField reflect.Value, vField reflect.Value) (bool, error) {
+	if !iField.IsValid() || !vField.IsValid() {
+		return false, nil
+	}
+	if iField.Kind() != reflect.Map || vField.Kind() != reflect.Slice {
+		return false, nil
+	}
+
+	elemType := vField.Type().Elem()
+	if s.options.entries == entriesPairs {
+		if err := pairFields(elemType); err != nil {
+			return false, err
+		}
+	}
+
+	keys := sortedKeys(iField.MapKeys())
+	newSlice := reflect.MakeSlice(vField.Type(), 0, len(keys))
+	for _, key := range keys {
+		if err := s.ctx.Err(); err != nil {
+			return false, err
+		}
+		newElem := reflect.New(elemType)
+		var err error
+		switch s.options.entries {
+		case entriesKeys:
+			err = s.applyField(key, newElem.Elem())
+		case entriesPairs:
+			err = s.applyPair(key, iField.MapIndex(key), newElem.Elem())
+		default:
+			err = s.applyField(iField.MapIndex(key), newElem.Elem())
+		}
+		if err != nil {
+			return false, fmt.Errorf("%v: %w", key, err)
+		}
+		newSlice = reflect.Append(newSlice, newElem.Elem())
+	}
+	vField.Set(newSlice)
+	return true, nil
+}
+
+// pairFields returns an error if map entries cannot be applied to the Key and Value fields of t.
+func pairFields(t reflect.Type) error {
+	pair := indirectType(t)
+	if pair.Kind() != reflect.Struct {
+		return fmt.Errorf("cannot apply map entries to '%v', it is not a struct", t)
+	}
+	return hasFields(pair, "Key", "Value")
+}
+
+// applyPair applies a map key and value to the Key and Value fields of the struct vField,
+// allocating it if vField is a pointer.
+func (s *state) applyPair(key reflect.Value, value reflect.Value, vField reflect.Value) error {
+	if vField.Kind() == reflect.Ptr {
+		vField.Set(reflect.New(vField.Type().Elem()))
+		vField = vField.Elem()
+	}
+	if err := s.applyField(key, vField.FieldByName("Key")); err != nil {
+		return fmt.Errorf("Key: %w", err)
+	}
+	if err := s.applyField(value, vField.FieldByName("Value")); err != nil {
+		return fmt.Errorf("Value: %w", err)
+	}
+	return nil
+}
+
+// arrange sorts and removes duplicates from the slice held by vField,
+// as set by the `s2s:"sort"` and `s2s:"dedupe"` options of the field tags.
+// Either option may name a field of struct elements to compare by, such as `s2s:"sort=Name"`.
+func (s *state) arrange(vField reflect.Value) error {
+	if !s.options.sort && !s.options.dedupe {
+		return nil
+	}
+	if vField.Kind() == reflect.Ptr && !vField.IsNil() {
+		vField = vField.Elem()
+	}
+	if vField.Kind() != reflect.Slice || !vField.CanSet() || vField.Len() == 0 {
+		return nil
+	}
+
+	// Arrange a copy, since the slice may share its array with the source.
+	arranged := reflect.MakeSlice(vField.Type(), vField.Len(), vField.Len())
+	reflect.Copy(arranged, vField)
+
+	if s.options.dedupe {
+		var err error
+		arranged, err = dedupe(arranged, s.options.dedupeBy)
+		if err != nil {
+			return err
+		}
+	}
+	if s.options.sort {
+		keys := make([]reflect.Value, arranged.Len())
+		for k := range keys {
+			key, err := sortKey(arranged.Index(k), s.options.sortBy)
+			if err != nil {
+				return err
+			}
+			keys[k] = key
+		}
+		order := make([]int, len(keys))
+		for k := range order {
+			order[k] = k
+		}
+		var err error
+		sort.SliceStable(order, func(a, b int) bool {
+			c, cmpErr := compareValues(keys[order[a]], keys[order[b]])
+			if cmpErr != nil && err == nil {
+				err = cmpErr
+			}
+			return c < 0
+		})
+		if err != nil {
+			return err
+		}
+		sorted := reflect.MakeSlice(arranged.Type(), 0, arranged.Len())
+		for _, k := range order {
+			sorted = reflect.Append(sorted, arranged.Index(k))
+		}
+		arranged = sorted
+	}
+	vField.Set(arranged)
+	return nil
+}
+
+// dedupe returns the elements of slice with the first of any duplicates,
+// compared by the field named by, or the elements themselves if by is empty.
+// Nil elements are duplicates of each other.
+func dedupe(slice reflect.Value, by string) (reflect.Value, error) {
+	seen := make(map[interface{}]bool)
+	seenNil := false
+	deduped := reflect.MakeSlice(slice.Type(), 0, slice.Len())
+	for k := 0; k < slice.Len(); k++ {
+		key, err := sortKey(slice.Index(k), by)
+		if err != nil {
+			return deduped, err
+		}
+		if !key.IsValid() {
+			if !seenNil {
+				seenNil = true
+				deduped = reflect.Append(deduped, slice.Index(k))
+			}
+			continue
+		}
+		if !key.CanInterface() || !key.Comparable() {
+			return deduped, fmt.Errorf("cannot dedupe values of type '%v'", concrete(key).Type())
+		}
+		if seen[key.Interface()] {
+			continue
+		}
+		seen[key.Interface()] = true
+		deduped = reflect.Append(deduped, slice.Index(k))
+	}
+	return deduped, nil
+}
+
+// sortKey returns the value elements are compared by: the field named by of struct
+// elements, or the element itself if by is empty. Pointers are dereferenced, with
+// nil pointers returning an invalid value.
+func sortKey(v reflect.Value, by string) (reflect.Value, error) {
+	for v.Kind() == reflect.Ptr || v.Kind() == reflect.Interface {
+		if v.IsNil() {
+			return reflect.Value{}, nil
+		}
+		v = v.Elem()
+	}
+	if by == "" {
+		return v, nil
+	}
+	if v.Kind() != reflect.Struct {
+		return v, fmt.Errorf("cannot compare '%v' by field '%v', it is not a struct", v.Type(), by)
+	}
+	field := v.FieldByName(by)
+	if !field.IsValid() {
+		return field, fmt.Errorf("no field '%v' in '%v'", by, v.Type())
+	}
+	return field, nil
+}
+
+// sortedKeys returns keys in ascending order of compareKeys.
+func sortedKeys(keys []reflect.Value) []reflect.Value {
+	sort.SliceStable(keys, func(a, b int) bool {
+		return compareKeys(keys[a], keys[b]) < 0
+	})
+	return keys
+}
+
+// compareKeys orders map keys by kind, then by value, so keys of interface maps
+// holding values of mixed types are always in the same order. Values that cannot be
+// compared directly are ordered by their formatted values, then by their type names.
+func compareKeys(a reflect.Value, b reflect.Value) int {
+	a, b = concrete(a), concrete(b)
+	if a.Kind() != b.Kind() {
+		return compareOrdered(int(a.Kind()), int(b.Kind()))
+	}
+	c, err := compareValues(a, b)
+	if err != nil {
+		c = strings.Compare(fmt.Sprint(a), fmt.Sprint(b))
+	}
+	if c != 0 {
+		return c
+	}
+	return strings.Compare(a.Type().String(), b.Type().String())
+}
+
+// compareValues compares numbers, strings and bools, returning a negative number if a is less than b,
+// zero if they are equal and a positive number if a is greater than b. Invalid values are less than any other.
+func compareValues(a reflect.Value, b reflect.Value) (int, error) {
+	if !a.IsValid() || !b.IsValid() {
+		switch {
+		case a.IsValid():
+			return 1, nil
+		case b.IsValid():
+			return -1, nil
+		}
+		return 0, nil
+	}
+	a, b = concrete(a), concrete(b)
+	if a.Kind() != b.Kind() {
+		return 0, fmt.Errorf("cannot compare '%v' to '%v'", a.Type(), b.Type())
+	}
+	switch a.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return compareOrdered(a.Int(), b.Int()), nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return compareOrdered(a.Uint(), b.Uint()), nil
+	case reflect.Float32, reflect.Float64:
+		return compareOrdered(a.Float(), b.Float()), nil
+	case reflect.String:
+		return strings.Compare(a.String(), b.String()), nil
+	case reflect.Bool:
+		return compareOrdered(boolInt(a.Bool()), boolInt(b.Bool())), nil
+	}
+	return 0, fmt.Errorf("cannot compare values of type '%v'", a.Type())
+}
+
+func compareOrdered[T int | int64 | uint64 | float64](a T, b T) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	}
+	return 0
+}
+
+func boolInt(b bool) int {
+	if b {
+		return 1
+	}
+	return 0
+}
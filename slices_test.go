@@ -0,0 +1,295 @@
+package struct2struct_test
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/theothertomelliott/struct2struct"
+)
+
+type entry struct {
+	Key   string
+	Value int
+}
+
+type person struct {
+	ID   int
+	Name string
+}
+
+func TestMarshalMapToSlice(t *testing.T) {
+	var scores = map[string]int{
+		"c": 3,
+		"a": 1,
+		"b": 2,
+	}
+	var tests = []marshalTest{
+		{
+			name:     "Values in key order",
+			in:       scores,
+			other:    &[]int64{},
+			expected: &[]int64{1, 2, 3},
+		},
+		{
+			name:     "Numeric keys",
+			in:       map[int]string{10: "ten", 2: "two", -1: "minus one"},
+			other:    &[]string{},
+			expected: &[]string{"minus one", "two", "ten"},
+		},
+		{
+			name: "Keys",
+			in: struct {
+				Scores map[string]int
+			}{
+				Scores: scores,
+			},
+			other: &struct {
+				Scores []string `s2s:"keys"`
+			}{},
+			expected: &struct {
+				Scores []string `s2s:"keys"`
+			}{
+				Scores: []string{"a", "b", "c"},
+			},
+		},
+		{
+			name: "Pairs",
+			in: struct {
+				Scores map[string]int
+			}{
+				Scores: scores,
+			},
+			other: &struct {
+				Scores []*entry `s2s:"pairs"`
+			}{},
+			expected: &struct {
+				Scores []*entry `s2s:"pairs"`
+			}{
+				Scores: []*entry{{Key: "a", Value: 1}, {Key: "b", Value: 2}, {Key: "c", Value: 3}},
+			},
+		},
+		{
+			name: "Mixed keys",
+			in: map[interface{}]string{
+				"b":      "string b",
+				2:        "int 2",
+				"a":      "string a",
+				int64(2): "int64 2",
+				1.5:      "float 1.5",
+				true:     "bool",
+				1:        "int 1",
+			},
+			other:    &[]string{},
+			expected: &[]string{"bool", "int 1", "int 2", "int64 2", "float 1.5", "string a", "string b"},
+		},
+		{
+			name: "Pairs without fields",
+			in: struct {
+				Scores map[string]int
+			}{
+				Scores: scores,
+			},
+			other: &struct {
+				Scores []person `s2s:"pairs"`
+			}{},
+			err: errors.New("Scores: no field 'Key' in 'struct2struct_test.person'"),
+		},
+	}
+	executeTests(t, tests)
+}
+
+func TestMarshalArrangeSlices(t *testing.T) {
+	var people = []person{
+		{ID: 3, Name: "carol"},
+		{ID: 1, Name: "alice"},
+		{ID: 2, Name: "bob"},
+		{ID: 1, Name: "alice again"},
+	}
+	var tests = []marshalTest{
+		{
+			name: "Sort and dedupe values",
+			in: struct {
+				Tags []string
+			}{
+				Tags: []string{"b", "a", "c", "a"},
+			},
+			other: &struct {
+				Tags []string `s2s:"sort,dedupe"`
+			}{},
+			expected: &struct {
+				Tags []string `s2s:"sort,dedupe"`
+			}{
+				Tags: []string{"a", "b", "c"},
+			},
+		},
+		{
+			name: "Sort by field",
+			in: struct {
+				People []person
+			}{
+				People: people,
+			},
+			other: &struct {
+				People []*person `s2s:"sort=Name"`
+			}{},
+			expected: &struct {
+				People []*person `s2s:"sort=Name"`
+			}{
+				People: []*person{
+					{ID: 1, Name: "alice"},
+					{ID: 1, Name: "alice again"},
+					{ID: 2, Name: "bob"},
+					{ID: 3, Name: "carol"},
+				},
+			},
+		},
+		{
+			name: "Dedupe by field",
+			in: struct {
+				People []person
+			}{
+				People: people,
+			},
+			other: &struct {
+				People []person `s2s:"dedupe=ID"`
+			}{},
+			expected: &struct {
+				People []person `s2s:"dedupe=ID"`
+			}{
+				People: people[:3],
+			},
+		},
+		{
+			name: "Dedupe nil elements",
+			in: struct {
+				People []*person
+			}{
+				People: []*person{nil, {ID: 1, Name: "alice"}, nil, {ID: 1, Name: "alice again"}},
+			},
+			other: &struct {
+				People []*person `s2s:"dedupe=ID"`
+			}{},
+			expected: &struct {
+				People []*person `s2s:"dedupe=ID"`
+			}{
+				People: []*person{nil, {ID: 1, Name: "alice"}},
+			},
+		},
+		{
+			name: "Dedupe uncomparable values",
+			in: struct {
+				Values []struct{ V interface{} }
+			}{
+				Values: []struct{ V interface{} }{{V: 1}, {V: []int{1}}},
+			},
+			other: &struct {
+				Values []struct{ V interface{} } `s2s:"dedupe=V"`
+			}{},
+			err: errors.New("Values: cannot dedupe values of type '[]int'"),
+		},
+		{
+			name: "Dedupe structs holding uncomparable values",
+			in: struct {
+				Values []struct{ V interface{} }
+			}{
+				Values: []struct{ V interface{} }{{V: []int{1}}},
+			},
+			other: &struct {
+				Values []struct{ V interface{} } `s2s:"dedupe"`
+			}{},
+			err: errors.New("Values: cannot dedupe values of type 'struct { V interface {} }'"),
+		},
+		{
+			name: "Sorted map values",
+			in: struct {
+				People map[string]person
+			}{
+				People: map[string]person{
+					"x": {ID: 2, Name: "bob"},
+					"y": {ID: 1, Name: "alice"},
+				},
+			},
+			other: &struct {
+				People []person `s2s:"sort=ID"`
+			}{},
+			expected: &struct {
+				People []person `s2s:"sort=ID"`
+			}{
+				People: []person{{ID: 1, Name: "alice"}, {ID: 2, Name: "bob"}},
+			},
+		},
+		{
+			name: "Unknown sort field",
+			in: struct {
+				People []person
+			}{
+				People: people,
+			},
+			other: &struct {
+				People []person `s2s:"sort=Age"`
+			}{},
+			err: errors.New("People: no field 'Age' in 'struct2struct_test.person'"),
+		},
+		{
+			name: "Unsortable values",
+			in: struct {
+				People []person
+			}{
+				People: people,
+			},
+			other: &struct {
+				People []person `s2s:"sort"`
+			}{},
+			err: errors.New("People: cannot compare values of type 'struct2struct_test.person'"),
+		},
+	}
+	executeTests(t, tests)
+}
+
+func TestArrangeDoesNotModifySource(t *testing.T) {
+	var in = struct {
+		Tags []string
+	}{
+		Tags: []string{"b", "a"},
+	}
+	var out struct {
+		Tags []string `s2s:"sort"`
+	}
+	if err := struct2struct.Marshal(in, &out); err != nil {
+		t.Fatal(err)
+	}
+	if expected := []string{"b", "a"}; !reflect.DeepEqual(expected, in.Tags) {
+		t.Errorf("source was modified, expected '%v', got '%v'", expected, in.Tags)
+	}
+	if expected := []string{"a", "b"}; !reflect.DeepEqual(expected, out.Tags) {
+		t.Errorf("values did not match, expected '%v', got '%v'", expected, out.Tags)
+	}
+}
+
+func TestValidateMapToSlice(t *testing.T) {
+	src := reflect.TypeOf(struct {
+		Scores map[string]int
+	}{})
+	for _, dst := range []reflect.Type{
+		reflect.TypeOf(struct {
+			Scores []int
+		}{}),
+		reflect.TypeOf(struct {
+			Scores []string `s2s:"keys"`
+		}{}),
+		reflect.TypeOf(struct {
+			Scores []entry `s2s:"pairs"`
+		}{}),
+	} {
+		if err := struct2struct.Validate(src, dst); err != nil {
+			t.Errorf("%v: %v", dst, err)
+		}
+	}
+	err := struct2struct.Validate(src, reflect.TypeOf(struct {
+		Scores []person `s2s:"pairs"`
+	}{}))
+	if err == nil {
+		t.Error("expected an error")
+	}
+}
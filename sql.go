@@ -0,0 +1,121 @@
+package struct2struct
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"reflect"
+)
+
+var (
+	scannerType = reflect.TypeOf((*sql.Scanner)(nil)).Elem()
+	valuerType  = reflect.TypeOf((*driver.Valuer)(nil)).Elem()
+)
+
+// sqlApplier converts values using sql.Scanner and driver.Valuer, so types such as
+// sql.NullString convert to and from their underlying values and pointers to them.
+// Targets implementing sql.Scanner scan driver.Valuer sources and driver values, after
+// unwrapping any driver.Valuer or pointer. Targets that hold driver values are applied the
+// value of a driver.Valuer, with a nil value setting the target to its zero value.
+// Other values, such as structs with matching fields, are left to the remaining appliers.
+func sqlApplier(s *state, iField reflect.Value, vField reflect.Value) (bool, error) {
+	if !iField.IsValid() || !vField.IsValid() || !iField.CanInterface() {
+		return false, nil
+	}
+	if !scans(iField.Type(), vField.Type()) {
+		return false, nil
+	}
+
+	if scanTo := scannerTarget(vField.Type()); scanTo != nil {
+		value, err := driverValue(iField)
+		if err != nil {
+			return false, err
+		}
+		newValue := reflect.New(scanTo)
+		err = newValue.Interface().(sql.Scanner).Scan(value)
+		if err != nil {
+			return false, err
+		}
+		if vField.Kind() == reflect.Ptr {
+			vField.Set(newValue)
+		} else {
+			vField.Set(newValue.Elem())
+		}
+		return true, nil
+	}
+
+	if iField.Kind() == reflect.Ptr && iField.IsNil() {
+		return false, nil
+	}
+	value, err := driverValue(iField)
+	if err != nil {
+		return false, err
+	}
+	if value == nil {
+		vField.Set(reflect.Zero(vField.Type()))
+		return true, nil
+	}
+	if vField.Kind() != reflect.Ptr {
+		err = s.applyField(reflect.ValueOf(value), vField)
+		return err == nil, err
+	}
+	// Valid values are applied to pointers of any type, such as sql.NullInt64 to *int.
+	newPtr := reflect.New(vField.Type().Elem())
+	err = s.applyField(reflect.ValueOf(value), newPtr.Elem())
+	if err != nil {
+		return false, err
+	}
+	vField.Set(newPtr)
+	return true, nil
+}
+
+// driverValue returns the value of v to be scanned, calling Value if v implements
+// driver.Valuer and following pointers. Nil pointers have a nil value.
+func driverValue(v reflect.Value) (interface{}, error) {
+	for {
+		if v.Kind() == reflect.Ptr && v.IsNil() {
+			return nil, nil
+		}
+		if implements(v.Type(), valuerType) {
+			return methodValue(v, valuerType).(driver.Valuer).Value()
+		}
+		if v.Kind() != reflect.Ptr {
+			return v.Interface(), nil
+		}
+		v = v.Elem()
+	}
+}
+
+// scannerTarget returns the type to scan values into for values of type t,
+// or nil if neither t nor the type it points to implements sql.Scanner.
+func scannerTarget(t reflect.Type) reflect.Type {
+	if t.Kind() == reflect.Ptr && t.Implements(scannerType) {
+		return t.Elem()
+	}
+	if t.Kind() != reflect.Ptr && t.Kind() != reflect.Interface && reflect.PtrTo(t).Implements(scannerType) {
+		return t
+	}
+	return nil
+}
+
+// scans reports whether values of type src are applied to dst by sqlApplier: driver.Valuer and
+// driver values scanned into sql.Scanner targets, and driver.Valuer values applied to driver values.
+func scans(src reflect.Type, dst reflect.Type) bool {
+	if scannerTarget(dst) != nil {
+		return implements(src, valuerType) || isDriverValue(src)
+	}
+	return implements(src, valuerType) && isDriverValue(dst)
+}
+
+// isDriverValue reports whether values of type t, after following pointers,
+// can be held by a driver.Value, such as strings, numbers, []byte and time.Time.
+func isDriverValue(t reflect.Type) bool {
+	t = indirectType(t)
+	switch t.Kind() {
+	case reflect.Bool, reflect.String, reflect.Interface,
+		reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Float32, reflect.Float64:
+		return true
+	}
+	return isBytes(t) || t == timeType
+}
@@ -0,0 +1,158 @@
+package struct2struct_test
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+)
+
+type dbRow struct {
+	Name     sql.NullString
+	Nickname sql.NullString
+	Age      sql.NullInt64
+	Created  sql.NullTime
+	Status   dbStatus
+}
+
+type domainRow struct {
+	Name     string
+	Nickname *string
+	Age      *int
+	Created  time.Time
+	Status   string
+}
+
+// dbStatus is stored as a single character.
+type dbStatus string
+
+func (s dbStatus) Value() (driver.Value, error) {
+	switch s {
+	case "A":
+		return "active", nil
+	case "I":
+		return "inactive", nil
+	}
+	return nil, nil
+}
+
+func (s *dbStatus) Scan(value interface{}) error {
+	switch value {
+	case "active":
+		*s = "A"
+	case "inactive":
+		*s = "I"
+	default:
+		return fmt.Errorf("unknown status %v", value)
+	}
+	return nil
+}
+
+// money is stored as a formatted string, but converts field by field to other structs.
+type money struct {
+	Amount   int64
+	Currency string
+}
+
+func (m money) Value() (driver.Value, error) {
+	return fmt.Sprintf("%d %v", m.Amount, m.Currency), nil
+}
+
+type moneyDTO struct {
+	Amount   int64
+	Currency string
+}
+
+func TestMarshalSQL(t *testing.T) {
+	created := time.Date(2020, 1, 2, 3, 4, 5, 0, time.UTC)
+	var tests = []marshalTest{
+		{
+			name: "Row to domain",
+			in: dbRow{
+				Name:    sql.NullString{String: "Jane", Valid: true},
+				Age:     sql.NullInt64{Int64: 30, Valid: true},
+				Created: sql.NullTime{Time: created, Valid: true},
+				Status:  "A",
+			},
+			other: &domainRow{},
+			expected: &domainRow{
+				Name:    "Jane",
+				Age:     intPtr(30),
+				Created: created,
+				Status:  "active",
+			},
+		},
+		{
+			name: "Domain to row",
+			in: domainRow{
+				Name:    "Jane",
+				Age:     intPtr(30),
+				Created: created,
+				Status:  "inactive",
+			},
+			other: &dbRow{},
+			expected: &dbRow{
+				Name:    sql.NullString{String: "Jane", Valid: true},
+				Age:     sql.NullInt64{Int64: 30, Valid: true},
+				Created: sql.NullTime{Time: created, Valid: true},
+				Status:  "I",
+			},
+		},
+		{
+			name:     "Null to pointer",
+			in:       []sql.NullInt64{{Int64: 1, Valid: true}, {}},
+			other:    &[]*int64{},
+			expected: &[]*int64{int64Ptr(1), nil},
+		},
+		{
+			name:     "Null between types",
+			in:       []sql.NullString{{String: "10", Valid: true}},
+			other:    &[]sql.NullInt64{},
+			expected: &[]sql.NullInt64{{Int64: 10, Valid: true}},
+		},
+		{
+			name: "Struct to scanner",
+			in: struct {
+				String string
+				Valid  bool
+			}{String: "x", Valid: true},
+			other:    &sql.NullString{},
+			expected: &sql.NullString{String: "x", Valid: true},
+		},
+		{
+			name:     "Valuer to struct",
+			in:       money{Amount: 100, Currency: "USD"},
+			other:    &moneyDTO{},
+			expected: &moneyDTO{Amount: 100, Currency: "USD"},
+		},
+		{
+			name:     "Valuer to pointer to struct",
+			in:       money{Amount: 100, Currency: "USD"},
+			other:    new(*moneyDTO),
+			expected: func() **moneyDTO { m := &moneyDTO{Amount: 100, Currency: "USD"}; return &m }(),
+		},
+		{
+			name:     "Valuer to string",
+			in:       []money{{Amount: 100, Currency: "USD"}},
+			other:    &[]string{},
+			expected: &[]string{"100 USD"},
+		},
+		{
+			name:  "Scan error",
+			in:    []string{"unknown"},
+			other: &[]dbStatus{},
+			err:   errors.New("unknown status unknown"),
+		},
+	}
+	executeTests(t, tests)
+}
+
+func intPtr(in int) *int {
+	return &in
+}
+
+func int64Ptr(in int64) *int64 {
+	return &in
+}
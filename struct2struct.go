@@ -1,57 +1,89 @@
 package struct2struct
 
 import (
-	"errors"
+	"context"
 	"fmt"
 	"reflect"
 )
 
 // Marshal processes i and applies its values to v.
 // Fields are matched first by s2s tags, then by field names.
-func Marshal(i interface{}, v interface{}) error {
-	if v == nil {
-		return errors.New("nil target")
-	}
-	if reflect.TypeOf(v).Kind() == reflect.Ptr {
-		return applyField(reflect.ValueOf(i), reflect.ValueOf(v).Elem())
-	}
-	return errors.New("expect target to be a pointer")
+func Marshal(i interface{}, v interface{}, opts ...Option) error {
+	return New(opts...).Marshal(i, v)
 }
 
-func mapFields(i interface{}, other interface{}) map[string]reflect.Value {
+// MarshalContext processes i and applies its values to v, as with Marshal.
+// Conversion stops with the context's error if ctx is cancelled.
+func MarshalContext(ctx context.Context, i interface{}, v interface{}, opts ...Option) error {
+	return New(opts...).MarshalContext(ctx, i, v)
+}
+
+// structField is a field of a struct value, keyed in mapFields by the name it matches.
+type structField struct {
+	reflect.StructField
+	rule  MatchRule
+	value reflect.Value
+}
 
-	var outFields = make(map[string]reflect.Value)
+// MatchRule describes how a field was given the name used to match it with fields of another type.
+type MatchRule string
+
+const (
+	// MatchPkgPathTag matches by a tag keyed on the full package path and name of the other type.
+	MatchPkgPathTag MatchRule = "package path tag"
+	// MatchTypeStringTag matches by a tag keyed on the short package and name of the other type.
+	MatchTypeStringTag MatchRule = "type string tag"
+	// MatchTypeNameTag matches by a tag keyed on the name of the other type.
+	MatchTypeNameTag MatchRule = "type name tag"
+	// MatchFieldName matches by the name of the field.
+	MatchFieldName MatchRule = "field name"
+	// MatchMapping matches by a field renamed in a registered Mapping.
+	MatchMapping MatchRule = "mapping"
+)
+
+func mapFields(i interface{}, other interface{}) map[string]structField {
 	iValue := reflect.Indirect(reflect.ValueOf(i))
-	iType := iValue.Type()
 
 	var otherType reflect.Type
 	if other != nil {
-		otherValue := reflect.ValueOf(other)
-		if reflect.TypeOf(other).Kind() == reflect.Ptr {
-			otherValue = reflect.Indirect(otherValue)
-		}
-		otherType = otherValue.Type()
+		otherType = indirectType(reflect.TypeOf(other))
 	}
 
-	for i := 0; i < iValue.NumField(); i++ {
-		fType := iType.Field(i)
-		fValue := iValue.Field(i)
-		tags := fType.Tag
+	outFields := matchFields(iValue.Type(), otherType)
+	for name, field := range outFields {
+		field.value = iValue.FieldByIndex(field.Index)
+		outFields[name] = field
+	}
+	return outFields
+}
+
+// matchFields returns the fields of the struct type t keyed by the name they match in otherType.
+// Fields are matched by tags keyed on the full package path, short package name or name
+// of otherType, then by field name. The values of the returned fields are not set.
+func matchFields(t reflect.Type, otherType reflect.Type) map[string]structField {
+	var outFields = make(map[string]structField)
+	for i := 0; i < t.NumField(); i++ {
+		field := structField{StructField: t.Field(i)}
+		tags := field.Tag
 		if otherType != nil {
 			if name, ok := tags.Lookup(fmt.Sprintf("%v.%v", otherType.PkgPath(), otherType.Name())); ok {
-				outFields[name] = fValue
+				field.rule = MatchPkgPathTag
+				outFields[name] = field
 				continue
 			}
 			if name, ok := tags.Lookup(otherType.String()); ok {
-				outFields[name] = fValue
+				field.rule = MatchTypeStringTag
+				outFields[name] = field
 				continue
 			}
 			if name, ok := tags.Lookup(otherType.Name()); ok {
-				outFields[name] = fValue
+				field.rule = MatchTypeNameTag
+				outFields[name] = field
 				continue
 			}
 		}
-		outFields[iType.Field(i).Name] = fValue
+		field.rule = MatchFieldName
+		outFields[field.Name] = field
 	}
 	return outFields
 }
@@ -65,3 +97,20 @@ type Marshaler interface {
 type Unmarshaler interface {
 	UnmarshalStruct(v interface{}) error
 }
+
+// BeforeStructConverter allows a struct to prepare for conversion to or from another struct.
+// BeforeStructConvert is called on both the source and the target before any fields are
+// applied, with a pointer to the counterpart struct as other. Sources receive a copy,
+// so normalizing the source does not modify the value passed to Marshal.
+// Structs converted to or from maps with string keys receive a pointer to the map as other.
+type BeforeStructConverter interface {
+	BeforeStructConvert(ctx context.Context, other interface{}) error
+}
+
+// AfterStructConverter allows a struct to complete conversion to or from another struct,
+// such as by computing derived fields. AfterStructConvert is called on both the source
+// and the target after all fields are applied, with a pointer to the counterpart struct,
+// or map with string keys, as other.
+type AfterStructConverter interface {
+	AfterStructConvert(ctx context.Context, other interface{}) error
+}
@@ -0,0 +1,226 @@
+// Package struct2structtest provides utilities for testing conversions made with struct2struct.
+package struct2structtest
+
+import (
+	"fmt"
+	"math/rand"
+	"reflect"
+	"testing"
+
+	"github.com/theothertomelliott/struct2struct"
+)
+
+// Config configures round-trip checks.
+type Config struct {
+	// Converter performs the conversions. If nil, a Converter with no options is used.
+	Converter *struct2struct.Converter
+	// Count is the number of random values to check. If zero, 100 values are checked.
+	Count int
+	// Rand is the source of random values. If nil, a source with a fixed seed is used,
+	// so failures can be reproduced.
+	Rand *rand.Rand
+	// MaxDepth limits how deeply nested random values may be. If zero, a depth of 3 is used.
+	MaxDepth int
+}
+
+// Mismatch describes a field that did not survive a round-trip conversion.
+type Mismatch struct {
+	// Path is the dotted path to the field, with indices and keys of collections in brackets.
+	Path string
+	// Original is the value that was generated, and RoundTripped the value after conversion.
+	Original     interface{}
+	RoundTripped interface{}
+}
+
+func (m Mismatch) String() string {
+	return fmt.Sprintf("%v: expected '%v', got '%v'", m.Path, m.Original, m.RoundTripped)
+}
+
+// RoundTrip generates random values of the type of src, converts each to the type of via
+// and back again with Marshal, and returns the fields that did not survive.
+// An error is returned if any conversion fails.
+func RoundTrip(src interface{}, via interface{}, config *Config) ([]Mismatch, error) {
+	config = config.withDefaults()
+	srcType := reflect.TypeOf(src)
+	viaType := reflect.TypeOf(via)
+
+	var mismatches []Mismatch
+	seen := make(map[string]bool)
+	for i := 0; i < config.Count; i++ {
+		original := config.value(srcType, 0)
+
+		converted := reflect.New(viaType)
+		if err := config.Converter.Marshal(original.Interface(), converted.Interface()); err != nil {
+			return mismatches, fmt.Errorf("converting '%v' to '%v': %w", srcType, viaType, err)
+		}
+		returned := reflect.New(srcType)
+		if err := config.Converter.Marshal(converted.Elem().Interface(), returned.Interface()); err != nil {
+			return mismatches, fmt.Errorf("converting '%v' to '%v': %w", viaType, srcType, err)
+		}
+
+		for _, mismatch := range diff("", original, returned.Elem()) {
+			// Report each field once, for the first value that did not survive.
+			if !seen[mismatch.Path] {
+				seen[mismatch.Path] = true
+				mismatches = append(mismatches, mismatch)
+			}
+		}
+	}
+	return mismatches, nil
+}
+
+// Check is like RoundTrip, but reports each mismatch or error as a test failure.
+func Check(t testing.TB, src interface{}, via interface{}, config *Config) {
+	t.Helper()
+	mismatches, err := RoundTrip(src, via, config)
+	if err != nil {
+		t.Error(err)
+	}
+	for _, mismatch := range mismatches {
+		t.Errorf("round trip via '%T' did not preserve %v", via, mismatch)
+	}
+}
+
+func (c *Config) withDefaults() *Config {
+	var out Config
+	if c != nil {
+		out = *c
+	}
+	if out.Converter == nil {
+		out.Converter = struct2struct.New()
+	}
+	if out.Count == 0 {
+		out.Count = 100
+	}
+	if out.Rand == nil {
+		out.Rand = rand.New(rand.NewSource(1))
+	}
+	if out.MaxDepth == 0 {
+		out.MaxDepth = 3
+	}
+	return &out
+}
+
+// value generates a random value of type t. Only exported struct fields are set,
+// and functions, channels and interfaces are left as zero values.
+func (c *Config) value(t reflect.Type, depth int) reflect.Value {
+	v := reflect.New(t).Elem()
+	r := c.Rand
+	switch t.Kind() {
+	case reflect.Bool:
+		v.SetBool(r.Intn(2) == 1)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		// Shift by a random amount so small and large magnitudes are both likely.
+		magnitude := r.Int63() >> uint(64-t.Bits()+r.Intn(t.Bits()))
+		v.SetInt(magnitude * int64(1-2*r.Intn(2)))
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		v.SetUint(r.Uint64() >> uint(64-t.Bits()+r.Intn(t.Bits())))
+	case reflect.Float32, reflect.Float64:
+		v.SetFloat(r.NormFloat64() * 1000)
+	case reflect.String:
+		letters := make([]byte, r.Intn(10))
+		for i := range letters {
+			letters[i] = byte('a' + r.Intn(26))
+		}
+		v.SetString(string(letters))
+	case reflect.Ptr:
+		if depth < c.MaxDepth && r.Intn(4) > 0 {
+			ptr := reflect.New(t.Elem())
+			ptr.Elem().Set(c.value(t.Elem(), depth+1))
+			v.Set(ptr)
+		}
+	case reflect.Slice:
+		if depth < c.MaxDepth {
+			n := r.Intn(4)
+			v.Set(reflect.MakeSlice(t, n, n))
+			for i := 0; i < n; i++ {
+				v.Index(i).Set(c.value(t.Elem(), depth+1))
+			}
+		}
+	case reflect.Array:
+		for i := 0; i < v.Len(); i++ {
+			v.Index(i).Set(c.value(t.Elem(), depth+1))
+		}
+	case reflect.Map:
+		if depth < c.MaxDepth {
+			v.Set(reflect.MakeMap(t))
+			for i := r.Intn(4); i > 0; i-- {
+				v.SetMapIndex(c.value(t.Key(), depth+1), c.value(t.Elem(), depth+1))
+			}
+		}
+	case reflect.Struct:
+		for i := 0; i < t.NumField(); i++ {
+			if t.Field(i).PkgPath == "" {
+				v.Field(i).Set(c.value(t.Field(i).Type, depth+1))
+			}
+		}
+	}
+	return v
+}
+
+// diff returns the paths at which a and b differ. Nil and empty collections are considered equal.
+func diff(path string, a reflect.Value, b reflect.Value) []Mismatch {
+	mismatch := func() []Mismatch {
+		return []Mismatch{{Path: path, Original: interfaceOf(a), RoundTripped: interfaceOf(b)}}
+	}
+	switch a.Kind() {
+	case reflect.Ptr:
+		if a.IsNil() || b.IsNil() {
+			if a.IsNil() != b.IsNil() {
+				return mismatch()
+			}
+			return nil
+		}
+		return diff(path, a.Elem(), b.Elem())
+	case reflect.Struct:
+		var out []Mismatch
+		for i := 0; i < a.NumField(); i++ {
+			if a.Type().Field(i).PkgPath == "" {
+				out = append(out, diff(joinPath(path, a.Type().Field(i).Name), a.Field(i), b.Field(i))...)
+			}
+		}
+		return out
+	case reflect.Slice, reflect.Array:
+		if a.Len() != b.Len() {
+			return mismatch()
+		}
+		var out []Mismatch
+		for i := 0; i < a.Len(); i++ {
+			out = append(out, diff(fmt.Sprintf("%v[%d]", path, i), a.Index(i), b.Index(i))...)
+		}
+		return out
+	case reflect.Map:
+		if a.Len() != b.Len() {
+			return mismatch()
+		}
+		var out []Mismatch
+		for _, key := range a.MapKeys() {
+			value := b.MapIndex(key)
+			if !value.IsValid() {
+				return mismatch()
+			}
+			out = append(out, diff(fmt.Sprintf("%v[%v]", path, key), a.MapIndex(key), value)...)
+		}
+		return out
+	case reflect.Func, reflect.Chan, reflect.Interface:
+		return nil
+	}
+	if !reflect.DeepEqual(interfaceOf(a), interfaceOf(b)) {
+		return mismatch()
+	}
+	return nil
+}
+
+func interfaceOf(v reflect.Value) interface{} {
+	if !v.IsValid() || !v.CanInterface() {
+		return nil
+	}
+	return v.Interface()
+}
+
+func joinPath(path string, name string) string {
+	if path == "" {
+		return name
+	}
+	return path + "." + name
+}
@@ -0,0 +1,65 @@
+package struct2structtest_test
+
+import (
+	"testing"
+
+	"github.com/theothertomelliott/struct2struct"
+	"github.com/theothertomelliott/struct2struct/struct2structtest"
+)
+
+type domain struct {
+	ID       int64
+	Name     string
+	Score    float64
+	Tags     []string
+	Children []*domain
+	Settings map[string]int
+}
+
+type dto struct {
+	ID       string
+	Name     string
+	Score    float64
+	Tags     []string
+	Children []*dto
+	Settings map[string]string
+}
+
+type narrowDTO struct {
+	ID   int8
+	Name string
+}
+
+func TestRoundTrip(t *testing.T) {
+	struct2structtest.Check(t, domain{}, dto{}, nil)
+}
+
+func TestRoundTripMismatches(t *testing.T) {
+	mismatches, err := struct2structtest.RoundTrip(domain{}, narrowDTO{}, &struct2structtest.Config{
+		Converter: struct2struct.New(),
+		Count:     20,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	var paths = make(map[string]bool)
+	for _, mismatch := range mismatches {
+		paths[mismatch.Path] = true
+	}
+	for _, expected := range []string{"ID", "Score", "Tags", "Settings"} {
+		if !paths[expected] {
+			t.Errorf("expected a mismatch for %v, got '%v'", expected, mismatches)
+		}
+	}
+	if paths["Name"] {
+		t.Errorf("expected Name to survive, got '%v'", mismatches)
+	}
+}
+
+func TestRoundTripError(t *testing.T) {
+	_, err := struct2structtest.RoundTrip(struct{ Enabled bool }{}, struct{ Enabled int }{}, nil)
+	expected := "converting 'struct { Enabled bool }' to 'struct { Enabled int }': Enabled: could not apply type 'bool' to 'int'"
+	if err == nil || err.Error() != expected {
+		t.Errorf("errors did not match, expected '%v', got '%v'", expected, err)
+	}
+}
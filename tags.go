@@ -0,0 +1,95 @@
+package struct2struct
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+const (
+	// optionsTag is the struct tag key holding comma separated options for a field,
+	// such as `s2s:"base64"`.
+	optionsTag = "s2s"
+	// formatTag is the struct tag key holding the format of a field, such as `format:"2006-01-02"`.
+	formatTag = "format"
+)
+
+// tagOptions holds the options set by the tags of the fields being applied.
+type tagOptions struct {
+	// encoding is the encoding used between byte slices and strings.
+	encoding string
+	// format is the format used between strings and numbers, times and durations.
+	format string
+	// entries selects whether maps applied to slices give their values, keys or pairs.
+	entries string
+	// sort and dedupe order slices and remove duplicates, comparing the fields
+	// named by sortBy and dedupeBy if set.
+	sort     bool
+	sortBy   string
+	dedupe   bool
+	dedupeBy string
+}
+
+// parseTags parses the options of each tag. Options set by earlier tags take precedence.
+func parseTags(tags ...reflect.StructTag) (tagOptions, error) {
+	var opts tagOptions
+	for _, tag := range tags {
+		if format, ok := tag.Lookup(formatTag); ok && opts.format == "" {
+			opts.format = format
+		}
+		value, ok := tag.Lookup(optionsTag)
+		if !ok {
+			continue
+		}
+		for _, option := range strings.Split(value, ",") {
+			option, arg, _ := strings.Cut(strings.TrimSpace(option), "=")
+			switch option {
+			case "":
+			case encodingBase64, encodingBase64URL, encodingHex:
+				if opts.encoding == "" {
+					opts.encoding = option
+				}
+			case entriesValues, entriesKeys, entriesPairs:
+				if opts.entries == "" {
+					opts.entries = option
+				}
+			case "sort":
+				if !opts.sort {
+					opts.sort, opts.sortBy = true, arg
+				}
+			case "dedupe":
+				if !opts.dedupe {
+					opts.dedupe, opts.dedupeBy = true, arg
+				}
+			default:
+				return opts, fmt.Errorf("unknown %v tag option '%v'", optionsTag, option)
+			}
+		}
+	}
+	return opts, nil
+}
+
+// withTags sets the options used while applying a field from the tags of the fields
+// it is read from and written to, returning a function that restores the previous options.
+func (s *state) withTags(tags ...reflect.StructTag) (func(), error) {
+	opts, err := parseTags(tags...)
+	if err != nil {
+		return nil, err
+	}
+	previous := s.options
+	s.options = opts
+	return func() { s.options = previous }, nil
+}
+
+// applyTagged applies iField to vField using the options of tags.
+func (s *state) applyTagged(iField reflect.Value, vField reflect.Value, tags ...reflect.StructTag) error {
+	restore, err := s.withTags(tags...)
+	if err != nil {
+		return err
+	}
+	defer restore()
+	if err := s.applyField(iField, vField); err != nil {
+		return err
+	}
+	return s.arrange(vField)
+}
@@ -0,0 +1,114 @@
+package struct2struct
+
+import (
+	"encoding"
+	"fmt"
+	"reflect"
+)
+
+var (
+	textMarshalerType   = reflect.TypeOf((*encoding.TextMarshaler)(nil)).Elem()
+	textUnmarshalerType = reflect.TypeOf((*encoding.TextUnmarshaler)(nil)).Elem()
+	stringerType        = reflect.TypeOf((*fmt.Stringer)(nil)).Elem()
+)
+
+// textApplier converts values using encoding.TextMarshaler and encoding.TextUnmarshaler.
+// Values are marshaled when applied to strings and byte slices, and targets are
+// unmarshaled from strings and byte slices. Other values, including pointers to and
+// from the same type, are left to the remaining appliers so they are kept exactly.
+// Values implementing fmt.Stringer are applied to strings using String.
+func textApplier(s *state, iField reflect.Value, vField reflect.Value) (bool, error) {
+	if !iField.IsValid() || !vField.IsValid() || !iField.CanInterface() {
+		return false, nil
+	}
+	if iField.Kind() == reflect.Ptr && iField.IsNil() {
+		return false, nil
+	}
+
+	unmarshalTo := textUnmarshalTarget(vField.Type())
+	marshals := isTextMarshaler(iField.Type())
+	switch {
+	case unmarshalTo != nil && isText(iField.Type()) && indirectType(iField.Type()) != unmarshalTo:
+		text, err := textOf(iField)
+		if err != nil {
+			return false, err
+		}
+		newValue := reflect.New(unmarshalTo)
+		err = newValue.Interface().(encoding.TextUnmarshaler).UnmarshalText(text)
+		if err != nil {
+			return false, err
+		}
+		if vField.Kind() == reflect.Ptr {
+			vField.Set(newValue)
+		} else {
+			vField.Set(newValue.Elem())
+		}
+		return true, nil
+	case marshals && isText(vField.Type()):
+		text, err := textOf(iField)
+		if err != nil {
+			return false, err
+		}
+		if vField.Kind() == reflect.String {
+			vField.SetString(string(text))
+		} else {
+			vField.SetBytes(text)
+		}
+		return true, nil
+	case vField.Kind() == reflect.String && implements(iField.Type(), stringerType):
+		vField.SetString(methodValue(iField, stringerType).(fmt.Stringer).String())
+		return true, nil
+	}
+	return false, nil
+}
+
+// textOf returns the text of v, using MarshalText if v implements encoding.TextMarshaler.
+func textOf(v reflect.Value) ([]byte, error) {
+	if isTextMarshaler(v.Type()) {
+		return methodValue(v, textMarshalerType).(encoding.TextMarshaler).MarshalText()
+	}
+	if v.Kind() == reflect.String {
+		return []byte(v.String()), nil
+	}
+	return v.Bytes(), nil
+}
+
+// methodValue returns v as an interface{} implementing iface, taking the address of
+// a copy of v if the methods of iface have pointer receivers.
+func methodValue(v reflect.Value, iface reflect.Type) interface{} {
+	if v.Type().Implements(iface) {
+		return v.Interface()
+	}
+	ptr := reflect.New(v.Type())
+	ptr.Elem().Set(v)
+	return ptr.Interface()
+}
+
+// textUnmarshalTarget returns the type to unmarshal text into for values of type t,
+// or nil if neither t nor the type it points to implements encoding.TextUnmarshaler.
+func textUnmarshalTarget(t reflect.Type) reflect.Type {
+	if t.Kind() == reflect.Ptr && t.Implements(textUnmarshalerType) {
+		return t.Elem()
+	}
+	if t.Kind() != reflect.Ptr && t.Kind() != reflect.Interface && reflect.PtrTo(t).Implements(textUnmarshalerType) {
+		return t
+	}
+	return nil
+}
+
+func isTextMarshaler(t reflect.Type) bool {
+	return implements(t, textMarshalerType)
+}
+
+// implements reports whether values of t, or pointers to them, implement iface.
+func implements(t reflect.Type, iface reflect.Type) bool {
+	if t.Implements(iface) {
+		return true
+	}
+	return t.Kind() != reflect.Ptr && t.Kind() != reflect.Interface && reflect.PtrTo(t).Implements(iface)
+}
+
+// isText reports whether t is a string or byte slice type.
+func isText(t reflect.Type) bool {
+	return t.Kind() == reflect.String || (t.Kind() == reflect.Slice && t.Elem().Kind() == reflect.Uint8)
+}
@@ -0,0 +1,153 @@
+package struct2struct_test
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"net"
+	"strings"
+	"testing"
+	"time"
+)
+
+type textLevel int
+
+const (
+	levelLow textLevel = iota
+	levelHigh
+)
+
+func (l textLevel) MarshalText() ([]byte, error) {
+	switch l {
+	case levelLow:
+		return []byte("low"), nil
+	case levelHigh:
+		return []byte("high"), nil
+	}
+	return nil, fmt.Errorf("unknown level %d", int(l))
+}
+
+func (l *textLevel) UnmarshalText(text []byte) error {
+	switch strings.ToLower(string(text)) {
+	case "low":
+		*l = levelLow
+	case "high":
+		*l = levelHigh
+	default:
+		return fmt.Errorf("unknown level %q", text)
+	}
+	return nil
+}
+
+type pointerStringer struct {
+	Name string
+}
+
+func (p *pointerStringer) String() string {
+	return "name: " + p.Name
+}
+
+var newYork = time.FixedZone("EST", -5*60*60)
+
+func TestMarshalText(t *testing.T) {
+	var tests = []marshalTest{
+		{
+			name:     "IP to string",
+			in:       net.IPv4(127, 0, 0, 1),
+			other:    stringPtr(""),
+			expected: stringPtr("127.0.0.1"),
+		},
+		{
+			name:     "String to IP",
+			in:       "127.0.0.1",
+			other:    &net.IP{},
+			expected: func() *net.IP { ip := net.ParseIP("127.0.0.1"); return &ip }(),
+		},
+		{
+			name:  "Invalid string to IP",
+			in:    "abc",
+			other: &net.IP{},
+			err:   errors.New("invalid IP address: abc"),
+		},
+		{
+			name: "Big int to and from strings",
+			in: struct {
+				Value *big.Int
+				Other big.Int
+			}{
+				Value: big.NewInt(12345),
+				Other: *big.NewInt(678),
+			},
+			other: &struct {
+				Value string
+				Other []byte
+			}{},
+			expected: &struct {
+				Value string
+				Other []byte
+			}{
+				Value: "12345",
+				Other: []byte("678"),
+			},
+		},
+		{
+			name: "String to big int",
+			in: struct {
+				Value string
+			}{
+				Value: "12345",
+			},
+			other: &struct {
+				Value *big.Int
+			}{},
+			expected: &struct {
+				Value *big.Int
+			}{
+				Value: big.NewInt(12345),
+			},
+		},
+		{
+			name:     "Enum to string",
+			in:       []textLevel{levelLow, levelHigh},
+			other:    &[]string{},
+			expected: &[]string{"low", "high"},
+		},
+		{
+			name:     "String to enum",
+			in:       []string{"HIGH", "low"},
+			other:    &[]textLevel{},
+			expected: &[]textLevel{levelHigh, levelLow},
+		},
+		{
+			name:  "Invalid enum",
+			in:    []string{"medium"},
+			other: &[]textLevel{},
+			err:   errors.New("unknown level \"medium\""),
+		},
+		{
+			name:     "Time to string",
+			in:       time.Date(2020, 1, 2, 3, 4, 5, 0, time.UTC),
+			other:    stringPtr(""),
+			expected: stringPtr("2020-01-02T03:04:05Z"),
+		},
+		{
+			name:     "Time to pointer",
+			in:       time.Date(2020, 1, 2, 3, 4, 5, 0, newYork),
+			other:    new(*time.Time),
+			expected: func() **time.Time { t := time.Date(2020, 1, 2, 3, 4, 5, 0, newYork); p := &t; return &p }(),
+		},
+		{
+			name:     "Pointer to time",
+			in:       func() *time.Time { t := time.Date(2020, 1, 2, 3, 4, 5, 0, newYork); return &t }(),
+			other:    &time.Time{},
+			expected: func() *time.Time { t := time.Date(2020, 1, 2, 3, 4, 5, 0, newYork); return &t }(),
+		},
+		{
+			name:     "Pointer receiver stringer",
+			in:       []pointerStringer{{Name: "abc"}},
+			other:    &[]string{},
+			expected: &[]string{"name: abc"},
+		},
+	}
+	executeTests(t, tests)
+}
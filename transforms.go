@@ -0,0 +1,173 @@
+package struct2struct
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// FieldTransform produces the value to apply to a target field in place of the value of a source field.
+// src is a pointer to a copy of the source struct the transform was registered for, and value is the field's value.
+// The returned value is applied to the target field as any other value would be.
+type FieldTransform func(ctx context.Context, src interface{}, value interface{}) (interface{}, error)
+
+// RegisterFieldTransform registers fn to be used when converting the field at path of structs
+// with the same type as src. The path is a field name, or a dotted path such as "Address.Street"
+// to reach fields of nested structs, including those held in pointers, slices and maps.
+// Transforms should be registered before c is used.
+func (c *Converter) RegisterFieldTransform(src interface{}, path string, fn FieldTransform) error {
+	t := indirectType(reflect.TypeOf(src))
+	if _, err := fieldByPath(t, path); err != nil {
+		return err
+	}
+	if c.transforms == nil {
+		c.transforms = make(map[fieldKey]FieldTransform)
+	}
+	c.transforms[fieldKey{typ: t, path: path}] = fn
+	return nil
+}
+
+// fieldKey identifies a field by the path to it from a struct type.
+type fieldKey struct {
+	typ  reflect.Type
+	path string
+}
+
+// fieldFrame records a struct field being applied.
+type fieldFrame struct {
+	src  interface{}
+	typ  reflect.Type
+	name string
+}
+
+// applyStructField applies a single matched field of the struct i,
+// using a registered transform if one applies.
+func (s *state) applyStructField(i interface{}, iField structField, vField structField) error {
+	s.fields = append(s.fields, fieldFrame{src: i, typ: indirectType(reflect.TypeOf(i)), name: iField.Name})
+	defer func() { s.fields = s.fields[:len(s.fields)-1] }()
+
+	restore, err := s.withTags(iField.Tag, vField.Tag)
+	if err != nil {
+		return err
+	}
+	defer restore()
+
+	transform, src := s.fieldTransform()
+	if transform == nil || !iField.value.CanInterface() || !vField.value.CanSet() {
+		err = s.applyField(iField.value, vField.value)
+	} else {
+		var value interface{}
+		value, err = transform(s.ctx, src, iField.value.Interface())
+		if err == nil {
+			err = s.applyValue(value, vField.value)
+		}
+	}
+	if err != nil {
+		return err
+	}
+	return s.arrange(vField.value)
+}
+
+// fieldTransform returns the transform registered for the field currently being applied,
+// preferring the most specific path, along with the struct it was registered for.
+func (s *state) fieldTransform() (FieldTransform, interface{}) {
+	if len(s.transforms) == 0 {
+		return nil, nil
+	}
+	var path string
+	for k := len(s.fields) - 1; k >= 0; k-- {
+		if path == "" {
+			path = s.fields[k].name
+		} else {
+			path = s.fields[k].name + "." + path
+		}
+		if fn, ok := s.transforms[fieldKey{typ: s.fields[k].typ, path: path}]; ok {
+			return fn, s.fields[k].src
+		}
+	}
+	return nil, nil
+}
+
+// hasTransforms reports whether values of type t may contain struct fields with registered transforms,
+// or structs with a Mapping registered to their own type, so must be applied field by field.
+func (s *state) hasTransforms(t reflect.Type) bool {
+	if len(s.transforms) == 0 && len(s.mappings) == 0 {
+		return false
+	}
+	if s.transformed == nil {
+		s.transformed = make(map[reflect.Type]bool)
+	}
+	found, ok := s.transformed[t]
+	if !ok {
+		found = s.containsTransforms(t, make(map[reflect.Type]bool))
+		s.transformed[t] = found
+	}
+	return found
+}
+
+func (s *state) containsTransforms(t reflect.Type, visited map[reflect.Type]bool) bool {
+	if visited[t] {
+		return false
+	}
+	visited[t] = true
+
+	switch t.Kind() {
+	case reflect.Ptr, reflect.Slice, reflect.Array:
+		return s.containsTransforms(t.Elem(), visited)
+	case reflect.Map:
+		return s.containsTransforms(t.Key(), visited) || s.containsTransforms(t.Elem(), visited)
+	case reflect.Struct:
+		if s.mappings[typePair{src: t, dst: t}] != nil {
+			return true
+		}
+		for key := range s.transforms {
+			if key.typ == t {
+				return true
+			}
+		}
+		for i := 0; i < t.NumField(); i++ {
+			if s.containsTransforms(t.Field(i).Type, visited) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// fieldByPath finds the field at a dotted path from the struct type t.
+func fieldByPath(t reflect.Type, path string) (reflect.StructField, error) {
+	var field reflect.StructField
+	for _, name := range strings.Split(path, ".") {
+		t = elemType(t)
+		if t.Kind() != reflect.Struct {
+			return field, fmt.Errorf("%v: '%v' is not a struct", path, t)
+		}
+		var ok bool
+		field, ok = t.FieldByName(name)
+		if !ok {
+			return field, fmt.Errorf("%v: no field '%v' in '%v'", path, name, t)
+		}
+		t = field.Type
+	}
+	return field, nil
+}
+
+// elemType returns the type of the values held by pointers, slices, arrays and maps of t.
+func elemType(t reflect.Type) reflect.Type {
+	for {
+		switch t.Kind() {
+		case reflect.Ptr, reflect.Slice, reflect.Array, reflect.Map:
+			t = t.Elem()
+		default:
+			return t
+		}
+	}
+}
+
+func indirectType(t reflect.Type) reflect.Type {
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	return t
+}
@@ -0,0 +1,110 @@
+package struct2struct_test
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/theothertomelliott/struct2struct"
+)
+
+type transformUser struct {
+	Name  string
+	Email string
+}
+
+type transformOrg struct {
+	Owner   transformUser
+	Members []*transformUser
+}
+
+func lowerEmail(ctx context.Context, src interface{}, value interface{}) (interface{}, error) {
+	return strings.ToLower(value.(string)), nil
+}
+
+func TestFieldTransforms(t *testing.T) {
+	c := struct2struct.New()
+	if err := c.RegisterFieldTransform(transformUser{}, "Email", lowerEmail); err != nil {
+		t.Fatal(err)
+	}
+	err := c.RegisterFieldTransform(&transformOrg{}, "Owner.Name", func(ctx context.Context, src interface{}, value interface{}) (interface{}, error) {
+		org := src.(*transformOrg)
+		return value.(string) + " (" + org.Owner.Email + ")", nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	in := transformOrg{
+		Owner: transformUser{Name: "Owner", Email: "Owner@Example.com"},
+		Members: []*transformUser{
+			{Name: "Member", Email: "Member@Example.com"},
+		},
+	}
+	var out struct {
+		Owner struct {
+			Name  string
+			Email string
+		}
+		Members []transformUser
+	}
+	if err := c.Marshal(in, &out); err != nil {
+		t.Fatal(err)
+	}
+	if out.Owner.Email != "owner@example.com" || out.Members[0].Email != "member@example.com" {
+		t.Errorf("expected emails to be lowercased, got '%v' and '%v'", out.Owner.Email, out.Members[0].Email)
+	}
+	if out.Owner.Name != "Owner (Owner@Example.com)" {
+		t.Errorf("expected name to be transformed by path, got '%v'", out.Owner.Name)
+	}
+	if out.Members[0].Name != "Member" {
+		t.Errorf("expected member name to be unchanged, got '%v'", out.Members[0].Name)
+	}
+}
+
+func TestFieldTransformErrors(t *testing.T) {
+	c := struct2struct.New()
+	err := c.RegisterFieldTransform(transformOrg{}, "Owner.Missing", lowerEmail)
+	expected := "Owner.Missing: no field 'Missing' in 'struct2struct_test.transformUser'"
+	if err == nil || err.Error() != expected {
+		t.Errorf("errors did not match, expected '%v', got '%v'", expected, err)
+	}
+
+	err = c.RegisterFieldTransform(transformUser{}, "Email", func(ctx context.Context, src interface{}, value interface{}) (interface{}, error) {
+		return nil, errors.New("invalid email")
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	var out transformUser
+	err = c.Marshal(transformUser{Email: "email"}, &out)
+	expected = "Email: invalid email"
+	if err == nil || err.Error() != expected {
+		t.Errorf("errors did not match, expected '%v', got '%v'", expected, err)
+	}
+}
+
+func TestFieldTransformsWithDeepCopy(t *testing.T) {
+	c := struct2struct.New(struct2struct.DeepCopy())
+	if err := c.RegisterFieldTransform(transformUser{}, "Email", lowerEmail); err != nil {
+		t.Fatal(err)
+	}
+
+	in := transformOrg{
+		Owner: transformUser{Email: "ABC"},
+		Members: []*transformUser{
+			{Email: "DEF"},
+		},
+	}
+	var out transformOrg
+	if err := c.Marshal(in, &out); err != nil {
+		t.Fatal(err)
+	}
+	if out.Owner.Email != "abc" || out.Members[0].Email != "def" {
+		t.Errorf("expected emails to be lowercased, got '%v' and '%v'", out.Owner.Email, out.Members[0].Email)
+	}
+	if out.Members[0] == in.Members[0] {
+		t.Error("expected members to be copied")
+	}
+}
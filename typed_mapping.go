@@ -0,0 +1,71 @@
+package struct2struct
+
+import "context"
+
+// TypedMapping builds a Mapping from the struct type Src to the struct type Dst.
+type TypedMapping[Src any, Dst any] struct {
+	mapping *Mapping
+}
+
+// NewMapping creates an empty mapping from Src to Dst.
+func NewMapping[Src any, Dst any]() *TypedMapping[Src, Dst] {
+	return &TypedMapping[Src, Dst]{
+		mapping: MappingFor((*Src)(nil), (*Dst)(nil)),
+	}
+}
+
+// Field applies the source field src to the target field dst, regardless of their names and tags.
+func (m *TypedMapping[Src, Dst]) Field(src string, dst string) *TypedMapping[Src, Dst] {
+	m.mapping.Field(src, dst)
+	return m
+}
+
+// Ignore prevents the source field src from being applied to any target field.
+func (m *TypedMapping[Src, Dst]) Ignore(src string) *TypedMapping[Src, Dst] {
+	m.mapping.Ignore(src)
+	return m
+}
+
+// Compute sets the target field dst from the source fields srcs using fn.
+func (m *TypedMapping[Src, Dst]) Compute(dst string, srcs []string, fn ComputeFunc) *TypedMapping[Src, Dst] {
+	m.mapping.Compute(dst, srcs, fn)
+	return m
+}
+
+// Spread sets the target fields dsts from the source field src using fn.
+func (m *TypedMapping[Src, Dst]) Spread(src string, dsts []string, fn SpreadFunc) *TypedMapping[Src, Dst] {
+	m.mapping.Spread(src, dsts, fn)
+	return m
+}
+
+// Convert adds fn to be called after all other fields have been applied.
+func (m *TypedMapping[Src, Dst]) Convert(fn func(ctx context.Context, src Src, dst *Dst) error) *TypedMapping[Src, Dst] {
+	m.mapping.Convert(func(ctx context.Context, src interface{}, dst interface{}) error {
+		return fn(ctx, *src.(*Src), dst.(*Dst))
+	})
+	return m
+}
+
+// Mapping returns the untyped Mapping built by m.
+func (m *TypedMapping[Src, Dst]) Mapping() *Mapping {
+	return m.mapping
+}
+
+// Register adds the mapping to those used by c.
+// An error is returned if the mapping names fields that do not exist.
+func (m *TypedMapping[Src, Dst]) Register(c *Converter) error {
+	return c.Register(m.mapping)
+}
+
+// Reverse returns a mapping from Dst to Src with renamed and ignored fields reversed.
+func (m *TypedMapping[Src, Dst]) Reverse() *TypedMapping[Dst, Src] {
+	return &TypedMapping[Dst, Src]{
+		mapping: m.mapping.Reverse(),
+	}
+}
+
+// RegisterBidirectional adds the mapping and its reverse to those used by c,
+// returning the fields that would not survive conversion in both directions.
+func (m *TypedMapping[Src, Dst]) RegisterBidirectional(c *Converter) ([]FieldError, error) {
+	return c.RegisterBidirectional(m.mapping)
+}
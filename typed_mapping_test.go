@@ -0,0 +1,119 @@
+package struct2struct_test
+
+import (
+	"context"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/theothertomelliott/struct2struct"
+)
+
+type sdkUser struct {
+	Name   string
+	Email  string
+	Secret string
+}
+
+type domainUser struct {
+	FullName string
+	Email    string
+	Secret   string
+	Domain   string
+}
+
+func TestTypedMapping(t *testing.T) {
+	c := struct2struct.New()
+	err := struct2struct.NewMapping[sdkUser, domainUser]().
+		Field("Name", "FullName").
+		Ignore("Secret").
+		Convert(func(ctx context.Context, src sdkUser, dst *domainUser) error {
+			dst.Domain = src.Email[strings.Index(src.Email, "@")+1:]
+			return nil
+		}).
+		Register(c)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var out []domainUser
+	err = c.Marshal([]*sdkUser{
+		{
+			Name:   "Jane Doe",
+			Email:  "jane@example.com",
+			Secret: "secret",
+		},
+	}, &out)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := []domainUser{
+		{
+			FullName: "Jane Doe",
+			Email:    "jane@example.com",
+			Domain:   "example.com",
+		},
+	}
+	if !reflect.DeepEqual(expected, out) {
+		t.Errorf("values did not match, expected '%v', got '%v'", expected, out)
+	}
+}
+
+func TestTypedMappingValidation(t *testing.T) {
+	var tests = []struct {
+		name    string
+		mapping *struct2struct.TypedMapping[sdkUser, domainUser]
+		err     string
+	}{
+		{
+			name:    "Unknown source field",
+			mapping: struct2struct.NewMapping[sdkUser, domainUser]().Field("Missing", "FullName"),
+			err:     "no field 'Missing' in 'struct2struct_test.sdkUser'",
+		},
+		{
+			name:    "Unknown target field",
+			mapping: struct2struct.NewMapping[sdkUser, domainUser]().Field("Name", "Missing"),
+			err:     "no field 'Missing' in 'struct2struct_test.domainUser'",
+		},
+		{
+			name:    "Unknown ignored field",
+			mapping: struct2struct.NewMapping[sdkUser, domainUser]().Ignore("Missing"),
+			err:     "no field 'Missing' in 'struct2struct_test.sdkUser'",
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			err := test.mapping.Register(struct2struct.New())
+			if err == nil || err.Error() != test.err {
+				t.Errorf("errors did not match, expected '%v', got '%v'", test.err, err)
+			}
+		})
+	}
+}
+
+func TestTypedMappingSameType(t *testing.T) {
+	for _, opts := range [][]struct2struct.Option{nil, {struct2struct.DeepCopy()}} {
+		c := struct2struct.New(opts...)
+		err := struct2struct.NewMapping[sdkUser, sdkUser]().
+			Ignore("Secret").
+			Register(c)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		var out []sdkUser
+		err = c.Marshal([]sdkUser{
+			{
+				Name:   "Jane Doe",
+				Secret: "secret",
+			},
+		}, &out)
+		if err != nil {
+			t.Fatal(err)
+		}
+		expected := []sdkUser{{Name: "Jane Doe"}}
+		if !reflect.DeepEqual(expected, out) {
+			t.Errorf("values did not match, expected '%v', got '%v'", expected, out)
+		}
+	}
+}
@@ -0,0 +1,58 @@
+package struct2struct
+
+import (
+	"fmt"
+	"reflect"
+	"unsafe"
+)
+
+// UnexportedFieldError is returned when StrictUnexported is set and an unexported
+// field matches a field of the other struct.
+type UnexportedFieldError struct {
+	Type  reflect.Type
+	Field string
+}
+
+func (e *UnexportedFieldError) Error() string {
+	return fmt.Sprintf("unexported field '%v' of '%v' cannot be applied", e.Field, e.Type)
+}
+
+// checkUnexported returns an error for matching fields of the structs i and v that are
+// not applied because one of them is unexported, if StrictUnexported is set.
+func (s *state) checkUnexported(i interface{}, iField structField, v interface{}, vField structField) error {
+	if err := s.checkField(reflect.TypeOf(i), iField); err != nil {
+		return err
+	}
+	return s.checkField(reflect.TypeOf(v), vField)
+}
+
+// checkField returns an error if field of the struct type t is unexported and
+// StrictUnexported is set, but UnexportedFields is not.
+func (s *state) checkField(t reflect.Type, field structField) error {
+	if !s.strictUnexported || s.unexportedFields || field.PkgPath == "" {
+		return nil
+	}
+	return &UnexportedFieldError{Type: indirectType(t), Field: field.Name}
+}
+
+// exposeFields makes the values of unexported fields readable and settable if UnexportedFields is set.
+// Fields of structs that are not addressable are left unchanged.
+func (s *state) exposeFields(fields map[string]structField) {
+	if !s.unexportedFields {
+		return
+	}
+	for name, field := range fields {
+		if field.PkgPath != "" {
+			field.value = expose(field.value)
+			fields[name] = field
+		}
+	}
+}
+
+// expose returns v, readable and settable if it is an unexported field of an addressable struct.
+func expose(v reflect.Value) reflect.Value {
+	if v.CanSet() || !v.CanAddr() {
+		return v
+	}
+	return reflect.NewAt(v.Type(), unsafe.Pointer(v.UnsafeAddr())).Elem()
+}
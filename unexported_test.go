@@ -0,0 +1,227 @@
+package struct2struct_test
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/theothertomelliott/struct2struct"
+)
+
+type unexportedSource struct {
+	Name   string
+	secret string
+	count  int
+}
+
+type unexportedTarget struct {
+	Name   string
+	secret string
+	count  int64
+}
+
+func TestMarshalUnexported(t *testing.T) {
+	var in = unexportedSource{
+		Name:   "name",
+		secret: "secret",
+		count:  2,
+	}
+	var tests = []marshalTest{
+		{
+			name:  "Dropped by default",
+			in:    in,
+			other: &unexportedTarget{},
+			expected: &unexportedTarget{
+				Name: "name",
+			},
+		},
+		{
+			name:  "Copied when enabled",
+			in:    in,
+			other: &unexportedTarget{},
+			expected: &unexportedTarget{
+				Name:   "name",
+				secret: "secret",
+				count:  2,
+			},
+			opts: []struct2struct.Option{struct2struct.UnexportedFields()},
+		},
+		{
+			name: "Nested when enabled",
+			in: struct {
+				Inner *unexportedSource
+			}{
+				Inner: &in,
+			},
+			other: &struct {
+				Inner unexportedTarget
+			}{},
+			expected: &struct {
+				Inner unexportedTarget
+			}{
+				Inner: unexportedTarget{
+					Name:   "name",
+					secret: "secret",
+					count:  2,
+				},
+			},
+			opts: []struct2struct.Option{struct2struct.UnexportedFields()},
+		},
+		{
+			name: "Map to unexported fields when enabled",
+			in: map[string]interface{}{
+				"secret": "secret",
+			},
+			other: &unexportedTarget{},
+			expected: &unexportedTarget{
+				secret: "secret",
+			},
+			opts: []struct2struct.Option{struct2struct.UnexportedFields()},
+		},
+		{
+			name:  "Dropped from maps by default",
+			in:    in,
+			other: &map[string]interface{}{},
+			expected: &map[string]interface{}{
+				"Name": "name",
+			},
+		},
+		{
+			name:  "Copied to maps when enabled",
+			in:    in,
+			other: &map[string]interface{}{},
+			expected: &map[string]interface{}{
+				"Name":   "name",
+				"secret": "secret",
+				"count":  2,
+			},
+			opts: []struct2struct.Option{struct2struct.UnexportedFields()},
+		},
+		{
+			name: "Strict struct to map",
+			in: struct {
+				count int
+			}{},
+			other: &map[string]interface{}{},
+			err:   errors.New("unexported field 'count' of 'struct { count int }' cannot be applied"),
+			opts:  []struct2struct.Option{struct2struct.StrictUnexported()},
+		},
+		{
+			name: "Strict map to struct",
+			in: map[string]interface{}{
+				"count": 2,
+			},
+			other: &struct {
+				count int64
+			}{},
+			err:  errors.New("unexported field 'count' of 'struct { count int64 }' cannot be applied"),
+			opts: []struct2struct.Option{struct2struct.StrictUnexported()},
+		},
+		{
+			name: "Strict",
+			in: struct {
+				count int
+			}{},
+			other: &struct {
+				count int64
+			}{},
+			err:  errors.New("count: unexported field 'count' of 'struct { count int }' cannot be applied"),
+			opts: []struct2struct.Option{struct2struct.StrictUnexported()},
+		},
+		{
+			name:  "Strict and enabled",
+			in:    in,
+			other: &unexportedTarget{},
+			expected: &unexportedTarget{
+				Name:   "name",
+				secret: "secret",
+				count:  2,
+			},
+			opts: []struct2struct.Option{struct2struct.StrictUnexported(), struct2struct.UnexportedFields()},
+		},
+	}
+	executeTests(t, tests)
+}
+
+func TestStrictUnexportedError(t *testing.T) {
+	err := struct2struct.Marshal(
+		struct{ name string }{},
+		&struct{ name []byte }{},
+		struct2struct.StrictUnexported(),
+	)
+	var unexportedErr *struct2struct.UnexportedFieldError
+	if !errors.As(err, &unexportedErr) {
+		t.Fatalf("expected an UnexportedFieldError, got '%v'", err)
+	}
+	if unexportedErr.Field != "name" {
+		t.Errorf("expected field 'name', got '%v'", unexportedErr.Field)
+	}
+}
+
+func TestValidateUnexported(t *testing.T) {
+	src := reflect.TypeOf(unexportedSource{})
+	dst := reflect.TypeOf(unexportedTarget{})
+	if err := struct2struct.Validate(src, dst); err != nil {
+		t.Error(err)
+	}
+	err := struct2struct.Validate(src, dst, struct2struct.StrictUnexported())
+	var validationErr *struct2struct.ValidationError
+	if !errors.As(err, &validationErr) {
+		t.Fatalf("expected a ValidationError, got '%v'", err)
+	}
+	if len(validationErr.Fields) != 2 {
+		t.Errorf("expected 2 field errors, got '%v'", err)
+	}
+
+	err = struct2struct.Validate(src, reflect.TypeOf(map[string]interface{}{}), struct2struct.StrictUnexported())
+	if !errors.As(err, &validationErr) {
+		t.Fatalf("expected a ValidationError, got '%v'", err)
+	}
+	if len(validationErr.Fields) != 2 {
+		t.Errorf("expected 2 field errors, got '%v'", err)
+	}
+}
+
+type unexportedTagged struct {
+	secret string `unexportedExported:"Secret"`
+}
+
+type unexportedExported struct {
+	Secret string
+}
+
+func TestMarshalUnexportedTagged(t *testing.T) {
+	var out unexportedExported
+	if err := struct2struct.Marshal(unexportedTagged{secret: "secret"}, &out); err != nil {
+		t.Fatal(err)
+	}
+	if out.Secret != "" {
+		t.Errorf("expected unexported field not to be read, got '%v'", out.Secret)
+	}
+
+	if err := struct2struct.Marshal(unexportedTagged{secret: "secret"}, &out, struct2struct.UnexportedFields()); err != nil {
+		t.Fatal(err)
+	}
+	if out.Secret != "secret" {
+		t.Errorf("expected unexported field to be read, got '%v'", out.Secret)
+	}
+
+	err := struct2struct.Marshal(unexportedTagged{secret: "secret"}, &out, struct2struct.StrictUnexported())
+	var unexportedErr *struct2struct.UnexportedFieldError
+	if !errors.As(err, &unexportedErr) {
+		t.Fatalf("expected an UnexportedFieldError, got '%v'", err)
+	}
+
+	src := reflect.TypeOf(unexportedTagged{})
+	dst := reflect.TypeOf(unexportedExported{})
+	if err := struct2struct.Validate(src, dst, struct2struct.StrictUnexported()); err == nil {
+		t.Error("expected a validation error")
+	}
+	explanation, err := struct2struct.Explain(src, dst)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if field := explanation.Fields[0]; field.Error != "unexported fields are not read" {
+		t.Errorf("expected an error for field Secret, got '%+v'", field)
+	}
+}
@@ -0,0 +1,311 @@
+package struct2struct
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+// FieldError describes a field that cannot be converted.
+type FieldError struct {
+	// Path is the dotted path to the field from the source type, empty for the types themselves.
+	Path string
+	Src  reflect.Type
+	Dst  reflect.Type
+	Err  error
+}
+
+func (e FieldError) Error() string {
+	if e.Path == "" {
+		return e.Err.Error()
+	}
+	return fmt.Sprintf("%v: %v", e.Path, e.Err)
+}
+
+// ValidationError lists every field that cannot be converted from one type to another.
+type ValidationError struct {
+	Src    reflect.Type
+	Dst    reflect.Type
+	Fields []FieldError
+}
+
+func (e *ValidationError) Error() string {
+	var fields []string
+	for _, field := range e.Fields {
+		fields = append(fields, field.Error())
+	}
+	return fmt.Sprintf("cannot convert '%v' to '%v': %v", e.Src, e.Dst, strings.Join(fields, "; "))
+}
+
+// Validate checks that values of type src can be applied to values of type dst,
+// returning a *ValidationError listing every field that cannot possibly be converted.
+// Conversions that depend on values, such as parsing strings as numbers, are assumed to succeed.
+func Validate(src reflect.Type, dst reflect.Type, opts ...Option) error {
+	return New(opts...).Validate(src, dst)
+}
+
+// MustValidate is like Validate but panics if the types cannot be converted.
+// It simplifies checking mappings when a package is initialized.
+func MustValidate(src reflect.Type, dst reflect.Type, opts ...Option) {
+	if err := Validate(src, dst, opts...); err != nil {
+		panic(err)
+	}
+}
+
+// Validate checks that values of type src can be applied to values of type dst
+// using the transforms and mappings registered with c.
+func (c *Converter) Validate(src reflect.Type, dst reflect.Type) error {
+	v := &validator{
+		Converter: c,
+		visiting:  make(map[typePair]bool),
+	}
+	v.check("", src, dst)
+	if len(v.fields) == 0 {
+		return nil
+	}
+	sort.SliceStable(v.fields, func(i, j int) bool {
+		return v.fields[i].Path < v.fields[j].Path
+	})
+	return &ValidationError{Src: src, Dst: dst, Fields: v.fields}
+}
+
+// validator walks two types following the same rules as the appliers.
+type validator struct {
+	*Converter
+
+	visiting map[typePair]bool
+	fields   []FieldError
+	// options holds the tag options of the struct fields being checked.
+	options tagOptions
+}
+
+func (v *validator) fail(path string, src reflect.Type, dst reflect.Type, format string, args ...interface{}) {
+	v.fields = append(v.fields, FieldError{
+		Path: path,
+		Src:  src,
+		Dst:  dst,
+		Err:  fmt.Errorf(format, args...),
+	})
+}
+
+// check reports any fields that cannot be applied from src to dst,
+// returning the name of the applier that would handle the types.
+func (v *validator) check(path string, src reflect.Type, dst reflect.Type) string {
+	switch {
+	case dst.Kind() == reflect.Interface:
+		if src.Kind() != reflect.Interface && !src.AssignableTo(dst) {
+			v.fail(path, src, dst, "type '%v' does not implement '%v'", src, dst)
+		}
+		return "interface"
+	case src == dst:
+		return "matchedType"
+	case src.Kind() == reflect.Interface:
+		// The concrete value is only known at runtime.
+		return "elem"
+	case (isFuncOrChan(src) || isFuncOrChan(dst)) && (src.AssignableTo(dst) || v.funcPolicy == FuncSkip):
+		return "func"
+	case isFuncOrChan(src) && src.Kind() == dst.Kind() && v.funcPolicy == FuncAdapt:
+		if err := canAdapt(src, dst); err != nil {
+			v.fail(path, src, dst, "%v", err)
+		} else if src.Kind() == reflect.Chan {
+			v.check(path, src.Elem(), dst.Elem())
+		} else {
+			for k := 0; k < src.NumIn(); k++ {
+				v.check(path, dst.In(k), src.In(k))
+			}
+			for k := 0; k < src.NumOut(); k++ {
+				v.check(path, src.Out(k), dst.Out(k))
+			}
+		}
+		return "func"
+	case v.isEnum(src, dst):
+		return "enum"
+	case scans(src, dst):
+		// Scanners and valuers convert values that are only known at runtime.
+		return "sql"
+	case v.formats(src, dst, v.options.format):
+		if (src == durationType || dst == durationType) && v.options.format != "" {
+			if _, err := durationUnit(v.options.format); err != nil {
+				v.fail(path, src, dst, "%v", err)
+			}
+		}
+		return "format"
+	case textUnmarshalTarget(dst) != nil && isText(src) && indirectType(src) != textUnmarshalTarget(dst):
+		return "text"
+	case isTextMarshaler(src) && isText(dst):
+		return "text"
+	case dst.Kind() == reflect.String && implements(src, stringerType):
+		return "text"
+	case (isBytes(src) || isRunes(src)) && dst.Kind() == reflect.String:
+		return "bytes"
+	case src.Kind() == reflect.String && (isBytes(dst) || isRunes(dst)):
+		return "bytes"
+	case src.Kind() == reflect.Ptr:
+		if dst.Kind() == reflect.Ptr {
+			v.check(path, src.Elem(), dst.Elem())
+		} else {
+			v.check(path, src.Elem(), dst)
+		}
+		return "pointer"
+	case dst.Kind() == reflect.Ptr && reflect.PtrTo(src) == dst:
+		return "pointer"
+	case dst.Kind() == reflect.Ptr && isStructOrMap(src) && isStructOrMap(dst.Elem()):
+		v.check(path, src, dst.Elem())
+		return "pointer"
+	case src.Kind() == reflect.Map && dst.Kind() == reflect.Slice:
+		switch v.options.entries {
+		case entriesKeys:
+			v.check(path, src.Key(), dst.Elem())
+		case entriesPairs:
+			if err := pairFields(dst.Elem()); err != nil {
+				v.fail(path, src, dst, "%v", err)
+			} else {
+				pair := indirectType(dst.Elem())
+				key, _ := pair.FieldByName("Key")
+				value, _ := pair.FieldByName("Value")
+				v.check(joinPath(path, "Key"), src.Key(), key.Type)
+				v.check(joinPath(path, "Value"), src.Elem(), value.Type)
+			}
+		default:
+			v.check(path, src.Elem(), dst.Elem())
+		}
+		return "mapSlice"
+	case src.Kind() == reflect.Slice || dst.Kind() == reflect.Slice:
+		if src.Kind() != reflect.Slice || dst.Kind() != reflect.Slice {
+			v.fail(path, src, dst, "cannot apply a non-slice value to a slice")
+		} else {
+			v.check(path, src.Elem(), dst.Elem())
+		}
+		return "slice"
+	case src.Kind() == reflect.Map && dst.Kind() == reflect.Struct && isStringMap(src):
+		for _, field := range matchFields(dst, src) {
+			if v.checkMapField(path, src, dst, field) {
+				v.check(joinPath(path, field.Name), src.Elem(), field.Type)
+			}
+		}
+		return "mapStruct"
+	case src.Kind() == reflect.Struct && dst.Kind() == reflect.Map && isStringMap(dst):
+		for _, field := range matchFields(src, dst) {
+			if v.checkMapField(path, src, dst, field) {
+				v.check(joinPath(path, field.Name), field.Type, dst.Elem())
+			}
+		}
+		return "mapStruct"
+	case src.Kind() == reflect.Map || dst.Kind() == reflect.Map:
+		if src.Kind() != reflect.Map || dst.Kind() != reflect.Map {
+			v.fail(path, src, dst, "cannot apply a map type to a non-map")
+		} else {
+			v.check(path, src.Key(), dst.Key())
+			v.check(path, src.Elem(), dst.Elem())
+		}
+		return "map"
+	case src.Kind() == reflect.Struct || dst.Kind() == reflect.Struct:
+		if src.Kind() != reflect.Struct || dst.Kind() != reflect.Struct {
+			v.fail(path, src, dst, "cannot apply a struct type to a non-struct")
+			return "struct"
+		}
+		pair := typePair{src: src, dst: dst}
+		if !v.visiting[pair] {
+			// Recursive types are checked once.
+			v.visiting[pair] = true
+			v.checkStruct(path, src, dst)
+			delete(v.visiting, pair)
+		}
+		return "struct"
+	case isNumber(dst) && (isNumber(src) || isComplex(src) || src.Kind() == reflect.String):
+		return numberApplier(dst)
+	case isComplex(dst) && (isNumber(src) || isComplex(src) || src.Kind() == reflect.String):
+		return "complex"
+	case dst.Kind() == reflect.String:
+		return "string"
+	}
+	v.fail(path, src, dst, "could not apply type '%v' to '%v'", src, dst)
+	return ""
+}
+
+func (v *validator) checkStruct(path string, src reflect.Type, dst reflect.Type) {
+	m := v.mappings[typePair{src: src, dst: dst}]
+	srcFields := matchFields(src, dst)
+	dstFields := matchFields(dst, src)
+	m.remap(srcFields, dstFields)
+
+	for name, dstField := range dstFields {
+		if _, ok := srcFields[name]; !ok && isTagRule(dstField.rule) {
+			v.fail(joinPath(path, dstField.Name), src, dstField.Type, "tag names '%v', which is not a field of '%v'", name, src)
+		}
+	}
+	for name, srcField := range srcFields {
+		dstField, ok := dstFields[name]
+		if !ok {
+			if isTagRule(srcField.rule) {
+				v.fail(joinPath(path, srcField.Name), srcField.Type, dst, "tag names '%v', which is not a field of '%v'", name, dst)
+			}
+			continue
+		}
+		if (srcField.PkgPath != "" || dstField.PkgPath != "") && !v.unexportedFields {
+			if v.strictUnexported {
+				v.fail(joinPath(path, srcField.Name), srcField.Type, dstField.Type, "unexported fields cannot be applied")
+			}
+			continue
+		}
+		opts, err := parseTags(srcField.Tag, dstField.Tag)
+		if err != nil {
+			v.fail(joinPath(path, srcField.Name), srcField.Type, dstField.Type, "%v", err)
+			continue
+		}
+		if _, ok := v.transforms[fieldKey{typ: src, path: srcField.Name}]; ok {
+			// Transforms may return values of any type.
+			continue
+		}
+		previous := v.options
+		v.options = opts
+		v.check(joinPath(path, srcField.Name), srcField.Type, dstField.Type)
+		v.options = previous
+	}
+}
+
+// checkMapField reports whether the struct field applied between src and dst should be checked,
+// failing if it is unexported and StrictUnexported is set.
+func (v *validator) checkMapField(path string, src reflect.Type, dst reflect.Type, field structField) bool {
+	if field.PkgPath == "" || v.unexportedFields {
+		return true
+	}
+	if v.strictUnexported {
+		v.fail(joinPath(path, field.Name), src, dst, "unexported fields cannot be applied")
+	}
+	return false
+}
+
+// isTagRule reports whether fields matched by rule were named by a tag.
+func isTagRule(rule MatchRule) bool {
+	return rule == MatchPkgPathTag || rule == MatchTypeStringTag || rule == MatchTypeNameTag
+}
+
+// numberApplier returns the name of the applier for the numeric type t.
+func numberApplier(t reflect.Type) string {
+	switch t.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return "int"
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return "uint"
+	}
+	return "float"
+}
+
+func isNumber(t reflect.Type) bool {
+	switch t.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Float32, reflect.Float64:
+		return true
+	}
+	return false
+}
+
+func joinPath(path string, name string) string {
+	if path == "" {
+		return name
+	}
+	return path + "." + name
+}
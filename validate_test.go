@@ -0,0 +1,164 @@
+package struct2struct_test
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/theothertomelliott/struct2struct"
+)
+
+type validateSource struct {
+	Name     string
+	Count    string
+	Sub      TwoIntsA
+	Items    []TwoIntsA
+	Settings map[string]string
+	Parent   *validateSource
+	Enabled  bool
+	Tags     []string
+	Callback func() int
+}
+
+type validateTarget struct {
+	Name     string
+	Count    int
+	Sub      *TwoIntsB
+	Items    []TwoIntsB
+	Settings struct{ Key string }
+	Parent   *validateTarget
+	Enabled  int
+	Tags     string
+	Callback func() string
+}
+
+type validateTagSource struct {
+	First  int
+	Second int `validateTagTarget:"SecondX"`
+}
+
+type validateTagTarget struct {
+	First   int
+	SecondB int
+	Third   int `validateTagSource:"Missing"`
+}
+
+func TestValidate(t *testing.T) {
+	var tests = []struct {
+		name   string
+		src    reflect.Type
+		dst    reflect.Type
+		fields []string
+	}{
+		{
+			name: "Convertible structs",
+			src:  reflect.TypeOf(TwoIntsA{}),
+			dst:  reflect.TypeOf(&TwoIntsB{}),
+		},
+		{
+			name: "Map to struct",
+			src:  reflect.TypeOf(map[string]interface{}{}),
+			dst:  reflect.TypeOf(TwoIntsB{}),
+		},
+		{
+			name: "Every incompatible field",
+			src:  reflect.TypeOf(validateSource{}),
+			dst:  reflect.TypeOf(validateTarget{}),
+			fields: []string{
+				"Callback: could not apply type 'func() int' to 'func() string'",
+				"Enabled: could not apply type 'bool' to 'int'",
+				"Tags: cannot apply a non-slice value to a slice",
+			},
+		},
+		{
+			name: "Unknown tag names",
+			src:  reflect.TypeOf(validateTagSource{}),
+			dst:  reflect.TypeOf(validateTagTarget{}),
+			fields: []string{
+				"Second: tag names 'SecondX', which is not a field of 'struct2struct_test.validateTagTarget'",
+				"Third: tag names 'Missing', which is not a field of 'struct2struct_test.validateTagSource'",
+			},
+		},
+		{
+			name: "Unknown tag option",
+			src: reflect.TypeOf(struct {
+				Data []byte `s2s:"base32"`
+			}{}),
+			dst: reflect.TypeOf(struct {
+				Data string
+			}{}),
+			fields: []string{"Data: unknown s2s tag option 'base32'"},
+		},
+		{
+			name:   "Struct to int",
+			src:    reflect.TypeOf([]TwoIntsA{}),
+			dst:    reflect.TypeOf([]int{}),
+			fields: []string{"cannot apply a struct type to a non-struct"},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			err := struct2struct.Validate(test.src, test.dst)
+			if len(test.fields) == 0 {
+				if err != nil {
+					t.Error(err)
+				}
+				return
+			}
+			var validationErr *struct2struct.ValidationError
+			if !errors.As(err, &validationErr) {
+				t.Fatalf("expected a ValidationError, got '%v'", err)
+			}
+			var fields = make(map[string]bool)
+			for _, field := range validationErr.Fields {
+				fields[field.Error()] = true
+			}
+			for _, expected := range test.fields {
+				if !fields[expected] {
+					t.Errorf("expected field error '%v' in '%v'", expected, err)
+				}
+			}
+			if len(fields) != len(test.fields) {
+				t.Errorf("expected %d field errors, got '%v'", len(test.fields), err)
+			}
+		})
+	}
+}
+
+func TestValidateWithMapping(t *testing.T) {
+	c := struct2struct.New()
+	err := struct2struct.NewMapping[validateSource, validateTarget]().
+		Ignore("Enabled").
+		Ignore("Tags").
+		Ignore("Callback").
+		Register(c)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := c.Validate(reflect.TypeOf(validateSource{}), reflect.TypeOf(validateTarget{})); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestValidateFuncPolicy(t *testing.T) {
+	src := reflect.TypeOf(callbackSource{})
+	dst := reflect.TypeOf(callbackTarget{})
+	if err := struct2struct.Validate(src, dst); err == nil {
+		t.Error("expected an error")
+	}
+	if err := struct2struct.Validate(src, dst, struct2struct.FuncFields(struct2struct.FuncSkip)); err != nil {
+		t.Error(err)
+	}
+	if err := struct2struct.Validate(src, dst, struct2struct.FuncFields(struct2struct.FuncAdapt)); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestMustValidate(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Error("expected a panic")
+		}
+	}()
+	struct2struct.MustValidate(reflect.TypeOf(""), reflect.TypeOf(TwoIntsA{}))
+}